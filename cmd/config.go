@@ -0,0 +1,596 @@
+package cmd
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/deadloct/bitheroes-hg-bot/game"
+	"github.com/deadloct/bitheroes-hg-bot/settings"
+	"github.com/deadloct/bitheroes-hg-bot/storage"
+	log "github.com/sirupsen/logrus"
+)
+
+// legacyNotificationRoles were hard-coded before guilds could configure their
+// own role. They're copied into the store for guilds that haven't got settings yet.
+var legacyNotificationRoles = map[string]string{
+	"1058940133929394176": "1127437645627273236", // Test Server
+	"1066742525357989899": "1127443645231005796", // Bot Support Server
+	"608309926569181217":  "1124455074962354216", // The Bitverse
+}
+
+// configSettings are the settings /hg-config reset can restore to their default.
+var configSettings = []string{
+	CommandConfigOptionNotificationRole,
+	CommandConfigOptionStartDelay,
+	CommandConfigOptionVictors,
+	CommandConfigOptionLocale,
+	CommandConfigEmoji,
+	CommandConfigTierRoles,
+	CommandConfigPermissions,
+	CommandConfigWinners,
+	CommandConfigWeights,
+}
+
+func configSettingChoices() []*discordgo.ApplicationCommandOptionChoice {
+	var choices []*discordgo.ApplicationCommandOptionChoice
+	for _, setting := range configSettings {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: setting, Value: setting})
+	}
+
+	return choices
+}
+
+func emojiChoices() []*discordgo.ApplicationCommandOptionChoice {
+	var choices []*discordgo.ApplicationCommandOptionChoice
+	for _, key := range settings.EmojiKeys {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: strings.ToLower(string(key)), Value: string(key)})
+	}
+
+	return choices
+}
+
+// MigrateLegacySettings stores the notification roles that used to be hard-coded.
+func (m *Manager) MigrateLegacySettings() error {
+	for guildID, role := range legacyNotificationRoles {
+		if _, err := m.store.GetGuildSettings(guildID); !errors.Is(err, storage.ErrNotFound) {
+			continue
+		}
+
+		if err := m.store.SaveGuildSettings(&storage.GuildSettings{GuildID: guildID, NotificationRole: role}); err != nil {
+			return err
+		}
+
+		log.Infof("migrated notification role %v for guild %v", role, guildID)
+	}
+
+	return nil
+}
+
+// guildSettings returns the guild's settings, or empty settings if it never
+// changed any or they can't be loaded.
+func (m *Manager) guildSettings(guildID string) *storage.GuildSettings {
+	gs, err := m.store.GetGuildSettings(guildID)
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			log.Errorf("could not load settings for guild %v, using the defaults: %v", guildID, err)
+		}
+
+		return &storage.GuildSettings{GuildID: guildID}
+	}
+
+	return gs
+}
+
+// guildEmojis converts the guild's emojis for the game.
+func guildEmojis(gs *storage.GuildSettings) map[settings.EmojiKey]settings.EmojiInfo {
+	emojis := make(map[settings.EmojiKey]settings.EmojiInfo)
+	for key, e := range gs.Emojis {
+		emojis[settings.EmojiKey(key)] = settings.EmojiInfo{Name: e.Name, ID: e.ID, Animated: e.Animated}
+	}
+
+	return emojis
+}
+
+func (m *Manager) handleConfig(session *discordgo.Session, ic *discordgo.InteractionCreate) {
+	if ic.Member.Permissions&(discordgo.PermissionAdministrator|discordgo.PermissionManageServer) == 0 {
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: "Only members with Manage Server can change the Hunger Games settings."})
+		return
+	}
+
+	gs, err := m.store.GetGuildSettings(ic.GuildID)
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		gs = &storage.GuildSettings{GuildID: ic.GuildID}
+	case err != nil:
+		log.Errorf("could not load settings for guild %v: %v", ic.GuildID, err)
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: "The Capitol archives are unavailable right now."})
+		return
+	}
+
+	sub := ic.ApplicationCommandData().Options[0]
+	var changed bool
+	switch sub.Name {
+	case CommandConfigSet:
+		changed = m.setConfig(session, ic, gs, sub)
+	case CommandConfigEmoji:
+		changed = m.setEmoji(session, ic, gs, sub)
+	case CommandConfigTierRole:
+		changed = setTierRole(gs, sub)
+	case CommandConfigWinners:
+		changed = m.setWinners(session, ic, gs, sub)
+	case CommandConfigWeights:
+		changed = m.setWeights(session, ic, gs, sub)
+	case CommandConfigReset:
+		changed = resetConfig(gs, sub.Options[0].StringValue())
+	case CommandConfigPermissions:
+		changed = m.changePermissions(session, ic, gs, sub.Options[0])
+	}
+
+	if changed {
+		if err := m.store.SaveGuildSettings(gs); err != nil {
+			log.Errorf("could not save settings for guild %v: %v", ic.GuildID, err)
+			m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: "The Capitol archives are unavailable right now."})
+			return
+		}
+
+		log.Infof("%v changed the settings of guild %v: %+v", ic.Member.User.ID, ic.GuildID, gs)
+	} else if sub.Name != CommandConfigShow {
+		// The change was rejected and the member was already told why.
+		return
+	}
+
+	var warning string
+	if sub.Name == CommandConfigSet && gs.NotificationRole != "" {
+		if err := m.canManageRole(session, ic.GuildID, gs.NotificationRole); err != nil {
+			warning = fmt.Sprintf("Members can't use `/hg-subscribe` yet because %v.", err)
+		}
+	}
+
+	m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{
+		Content:         warning,
+		Embeds:          []*discordgo.MessageEmbed{configEmbed(gs)},
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	})
+}
+
+func (m *Manager) setConfig(session *discordgo.Session, ic *discordgo.InteractionCreate, gs *storage.GuildSettings, sub *discordgo.ApplicationCommandInteractionDataOption) bool {
+	if len(sub.Options) == 0 {
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: "Please choose at least one setting to change."})
+		return false
+	}
+
+	for _, option := range sub.Options {
+		switch option.Name {
+		case CommandConfigOptionNotificationRole:
+			gs.NotificationRole = option.RoleValue(nil, "").ID
+
+		case CommandConfigOptionStartDelay:
+			v := option.FloatValue()
+			if v < settings.MinimumStartDelay || v > settings.MaximumStartDelay {
+				m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{
+					Content: fmt.Sprintf("The start delay must be between %v and %v minutes.", settings.MinimumStartDelay, settings.MaximumStartDelay),
+				})
+				return false
+			}
+			gs.StartDelay = time.Duration(v * float64(time.Minute))
+
+		case CommandConfigOptionVictors:
+			v := int(option.IntValue())
+			if v <= settings.MinimumVictorCount {
+				m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: "There must be at least one victor."})
+				return false
+			}
+			gs.VictorCount = v
+
+		case CommandConfigOptionLocale:
+			v := strings.TrimSpace(option.StringValue())
+			if !settings.IsLocale(v) {
+				m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{
+					Content: fmt.Sprintf("The Capitol doesn't speak %q yet. Available locales: %v.", v, strings.Join(settings.Locales, ", ")),
+				})
+				return false
+			}
+			gs.Locale = v
+		}
+	}
+
+	return true
+}
+
+func (m *Manager) setEmoji(session *discordgo.Session, ic *discordgo.InteractionCreate, gs *storage.GuildSettings, sub *discordgo.ApplicationCommandInteractionDataOption) bool {
+	var key, value string
+	for _, option := range sub.Options {
+		switch option.Name {
+		case CommandConfigOptionEmojiName:
+			key = option.StringValue()
+		case CommandConfigOptionEmoji:
+			value = strings.TrimSpace(option.StringValue())
+		}
+	}
+
+	e, ok := settings.ParseEmoji(value)
+	if !ok {
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: "Please pick a custom emoji from this server, like :hungergames:."})
+		return false
+	}
+
+	if err := canUseEmoji(session, ic.GuildID, e.ID); err != nil {
+		log.Infof("rejected emoji %v for guild %v: %v", value, ic.GuildID, err)
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: fmt.Sprintf("The Capitol can't use %v because %v.", value, err)})
+		return false
+	}
+
+	if gs.Emojis == nil {
+		gs.Emojis = make(map[string]storage.EmojiRecord)
+	}
+
+	gs.Emojis[key] = storage.EmojiRecord{Name: e.Name, ID: e.ID, Animated: e.Animated}
+	return true
+}
+
+// canUseEmoji checks that emojiID belongs to the guild and that the bot may
+// react with it.
+func canUseEmoji(session *discordgo.Session, guildID, emojiID string) error {
+	emojis, err := session.GuildEmojis(guildID)
+	if err != nil {
+		log.Errorf("could not load the emojis of guild %v: %v", guildID, err)
+		return errors.New("this server's emojis couldn't be loaded")
+	}
+
+	i := slices.IndexFunc(emojis, func(e *discordgo.Emoji) bool { return e.ID == emojiID })
+	switch {
+	case i < 0:
+		return errors.New("it isn't an emoji of this server")
+	case !emojis[i].Available:
+		return errors.New("it is unavailable, perhaps the server lost a boost level")
+	case len(emojis[i].Roles) == 0:
+		return nil
+	}
+
+	bot, err := session.GuildMember(guildID, session.State.User.ID)
+	if err != nil {
+		log.Errorf("could not load the bot's roles in guild %v: %v", guildID, err)
+		return errors.New("the bot's roles couldn't be loaded")
+	}
+
+	if !slices.ContainsFunc(emojis[i].Roles, func(role string) bool { return slices.Contains(bot.Roles, role) }) {
+		return errors.New("it is limited to roles the bot doesn't have")
+	}
+
+	return nil
+}
+
+func setTierRole(gs *storage.GuildSettings, sub *discordgo.ApplicationCommandInteractionDataOption) bool {
+	var roleID string
+	var tier int
+	for _, option := range sub.Options {
+		switch option.Name {
+		case CommandConfigOptionRole:
+			roleID = option.RoleValue(nil, "").ID
+		case CommandConfigOptionTier:
+			tier = int(option.IntValue())
+		}
+	}
+
+	if tier <= 0 {
+		delete(gs.TierRoles, roleID)
+		return true
+	}
+
+	if gs.TierRoles == nil {
+		gs.TierRoles = make(map[string]int)
+	}
+
+	gs.TierRoles[roleID] = tier
+	return true
+}
+
+func (m *Manager) setWinners(session *discordgo.Session, ic *discordgo.InteractionCreate, gs *storage.GuildSettings, sub *discordgo.ApplicationCommandInteractionDataOption) bool {
+	if len(sub.Options) == 0 {
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: "Please choose at least one setting to change."})
+		return false
+	}
+
+	var policy storage.WinnerPolicy
+	if gs.Winners != nil {
+		policy = *gs.Winners
+	}
+
+	for _, option := range sub.Options {
+		switch option.Name {
+		case CommandConfigOptionCooldownDays:
+			policy.CooldownDays = int(option.IntValue())
+		case CommandConfigOptionCooldownGames:
+			policy.CooldownGames = int(option.IntValue())
+		case CommandConfigOptionHandicapDays:
+			policy.HandicapDays = int(option.IntValue())
+		case CommandConfigOptionHandicapPercent:
+			policy.HandicapPercent = int(option.IntValue())
+		}
+	}
+
+	if policy.HandicapDays > 0 && (policy.HandicapPercent <= 0 || policy.HandicapPercent >= 100) {
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{
+			Content: fmt.Sprintf("Please also set %v between 1 and 99 to lower the odds of recent victors.", CommandConfigOptionHandicapPercent),
+		})
+		return false
+	}
+
+	gs.Winners = &policy
+	if policy == (storage.WinnerPolicy{}) {
+		gs.Winners = nil
+	}
+
+	return true
+}
+
+func (m *Manager) setWeights(session *discordgo.Session, ic *discordgo.InteractionCreate, gs *storage.GuildSettings, sub *discordgo.ApplicationCommandInteractionDataOption) bool {
+	if len(sub.Options) == 0 {
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: "Please choose at least one setting to change."})
+		return false
+	}
+
+	var weights storage.SurvivalWeights
+	if gs.Weights != nil {
+		weights = *gs.Clone().Weights
+	}
+
+	var roleID string
+	rolePercent := -1
+	for _, option := range sub.Options {
+		switch option.Name {
+		case CommandConfigOptionBoosterPercent:
+			weights.BoosterPercent = int(option.IntValue())
+		case CommandConfigOptionPityLosses:
+			weights.PityLosses = int(option.IntValue())
+		case CommandConfigOptionPityPercent:
+			weights.PityPercent = int(option.IntValue())
+		case CommandConfigOptionRole:
+			roleID = option.RoleValue(nil, "").ID
+		case CommandConfigOptionRolePercent:
+			rolePercent = int(option.IntValue())
+		}
+	}
+
+	if (roleID == "") != (rolePercent < 0) {
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{
+			Content: fmt.Sprintf("Please set %v and %v together.", CommandConfigOptionRole, CommandConfigOptionRolePercent),
+		})
+		return false
+	}
+
+	if (weights.PityLosses > 0) != (weights.PityPercent > 0) {
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{
+			Content: fmt.Sprintf("Please set both %v and %v to help tributes on a losing streak.", CommandConfigOptionPityLosses, CommandConfigOptionPityPercent),
+		})
+		return false
+	}
+
+	switch {
+	case roleID == "":
+	case rolePercent == 0:
+		delete(weights.Roles, roleID)
+	default:
+		if weights.Roles == nil {
+			weights.Roles = make(map[string]int)
+		}
+		weights.Roles[roleID] = rolePercent
+	}
+
+	gs.Weights = &weights
+	if weights.IsEmpty() {
+		gs.Weights = nil
+	}
+
+	return true
+}
+
+// guildHistory returns the guild's games when its winner policy or pity weight
+// needs them. Those are skipped if the history can't be loaded rather than
+// blocking the game.
+func (m *Manager) guildHistory(gs *storage.GuildSettings) []*storage.GameRecord {
+	if gs.Winners == nil && (gs.Weights == nil || gs.Weights.PityLosses == 0) {
+		return nil
+	}
+
+	recs, _, err := m.store.ListGames(gs.GuildID, 0, 0)
+	if err != nil {
+		log.Errorf("could not load the history of guild %v, ignoring its winner policy and pity weight: %v", gs.GuildID, err)
+		return nil
+	}
+
+	return recs
+}
+
+// winnerPolicy applies the guild's winner policy to its finished games.
+func winnerPolicy(gs *storage.GuildSettings, history []*storage.GameRecord) *game.WinnerPolicy {
+	if gs.Winners == nil {
+		return nil
+	}
+
+	policy := &game.WinnerPolicy{
+		CooldownDays:   gs.Winners.CooldownDays,
+		CooldownGames:  gs.Winners.CooldownGames,
+		HandicapDays:   gs.Winners.HandicapDays,
+		HandicapWeight: float64(gs.Winners.HandicapPercent) / 100,
+	}
+
+	if !policy.IsActive() {
+		return nil
+	}
+
+	policy.ApplyHistory(history, time.Now())
+	return policy
+}
+
+func survivalWeighers(gs *storage.GuildSettings, history []*storage.GameRecord) []game.Weigher {
+	if gs.Weights == nil {
+		return nil
+	}
+
+	var weighers []game.Weigher
+	if gs.Weights.BoosterPercent > 0 && gs.Weights.BoosterPercent != 100 {
+		weighers = append(weighers, game.BoosterWeight(float64(gs.Weights.BoosterPercent)/100))
+	}
+
+	if gs.Weights.PityLosses > 0 && gs.Weights.PityPercent > 0 && gs.Weights.PityPercent != 100 {
+		weighers = append(weighers, game.NewPityWeight(gs.Weights.PityLosses, float64(gs.Weights.PityPercent)/100, history))
+	}
+
+	if len(gs.Weights.Roles) > 0 {
+		roles := make(game.RoleWeights)
+		for role, pct := range gs.Weights.Roles {
+			roles[role] = float64(pct) / 100
+		}
+		weighers = append(weighers, roles)
+	}
+
+	return weighers
+}
+
+func survivalWeightsLine(w *storage.SurvivalWeights) string {
+	if w == nil {
+		return "usual odds for everybody"
+	}
+
+	var parts []string
+	if w.BoosterPercent > 0 {
+		parts = append(parts, fmt.Sprintf("boosters %v%%", w.BoosterPercent))
+	}
+
+	if w.PityLosses > 0 {
+		parts = append(parts, fmt.Sprintf("%v%% after %v losses in a row", w.PityPercent, w.PityLosses))
+	}
+
+	roles := slices.Sorted(maps.Keys(w.Roles))
+	for _, role := range roles {
+		parts = append(parts, fmt.Sprintf("<@&%v> %v%%", role, w.Roles[role]))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func winnerPolicyLine(p *storage.WinnerPolicy) string {
+	if p == nil {
+		return "off"
+	}
+
+	var parts []string
+	if p.CooldownDays > 0 {
+		parts = append(parts, fmt.Sprintf("can't enter for %v days", p.CooldownDays))
+	}
+
+	if p.CooldownGames > 0 {
+		parts = append(parts, fmt.Sprintf("can't enter the next %v games", p.CooldownGames))
+	}
+
+	if p.HandicapDays > 0 {
+		parts = append(parts, fmt.Sprintf("%v%% survival odds for %v days", p.HandicapPercent, p.HandicapDays))
+	}
+
+	if len(parts) == 0 {
+		return "off"
+	}
+
+	return "victors " + strings.Join(parts, ", ")
+}
+
+func resetConfig(gs *storage.GuildSettings, setting string) bool {
+	switch setting {
+	case CommandConfigOptionNotificationRole:
+		gs.NotificationRole = ""
+	case CommandConfigOptionStartDelay:
+		gs.StartDelay = 0
+	case CommandConfigOptionVictors:
+		gs.VictorCount = 0
+	case CommandConfigOptionLocale:
+		gs.Locale = ""
+	case CommandConfigEmoji:
+		gs.Emojis = nil
+	case CommandConfigTierRoles:
+		gs.TierRoles = nil
+	case CommandConfigPermissions:
+		gs.Permissions = nil
+	case CommandConfigWinners:
+		gs.Winners = nil
+	case CommandConfigWeights:
+		gs.Weights = nil
+	default:
+		return false
+	}
+
+	return true
+}
+
+func tierRoleList(tierRoles map[string]int) string {
+	if len(tierRoles) == 0 {
+		return "none, so minimum-tier isn't enforced"
+	}
+
+	roles := slices.SortedFunc(maps.Keys(tierRoles), func(a, b string) int { return cmp.Compare(tierRoles[a], tierRoles[b]) })
+
+	var list []string
+	for _, role := range roles {
+		list = append(list, fmt.Sprintf("<@&%v> (Tier %v)", role, tierRoles[role]))
+	}
+
+	return strings.Join(list, ", ")
+}
+
+func configEmbed(gs *storage.GuildSettings) *discordgo.MessageEmbed {
+	role := "none"
+	if gs.NotificationRole != "" {
+		role = fmt.Sprintf("<@&%v>", gs.NotificationRole)
+	}
+
+	delay := fmt.Sprintf("%v (default)", time.Duration(settings.DefaultStartDelay*float64(time.Minute)))
+	if gs.StartDelay > 0 {
+		delay = gs.StartDelay.String()
+	}
+
+	victors := fmt.Sprintf("%v (default)", settings.DefaultVictorCount)
+	if gs.VictorCount > 0 {
+		victors = fmt.Sprint(gs.VictorCount)
+	}
+
+	locale := fmt.Sprintf("%v (default)", settings.DefaultLocale)
+	if gs.Locale != "" {
+		locale = gs.Locale
+	}
+
+	emojis := guildEmojis(gs)
+	var emojiLines []string
+	for _, key := range settings.EmojiKeys {
+		e, ok := emojis[key]
+		if !ok {
+			e = settings.GetEmoji(key)
+		}
+
+		emojiLines = append(emojiLines, fmt.Sprintf("• %v: %v", strings.ToLower(string(key)), e.EmojiCode()))
+	}
+
+	lines := []string{
+		fmt.Sprintf("**Notification role:** %v", role),
+		fmt.Sprintf("**Start delay:** %v", delay),
+		fmt.Sprintf("**Victors:** %v", victors),
+		fmt.Sprintf("**Locale:** %v", locale),
+		"**Emojis:**",
+		strings.Join(emojiLines, "\n"),
+		fmt.Sprintf("**Tier roles:** %v", tierRoleList(gs.TierRoles)),
+		fmt.Sprintf("**Winner policy:** %v", winnerPolicyLine(gs.Winners)),
+		fmt.Sprintf("**Survival odds:** %v", survivalWeightsLine(gs.Weights)),
+		"**Permissions:**",
+		permissionLines(gs),
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       "Hunger Games settings for this server",
+		Description: strings.Join(lines, "\n"),
+	}
+}
@@ -0,0 +1,89 @@
+// Command hg-verify recomputes the victors of a provably fair Hunger Games event
+// from the seed and entrant list revealed by the bot.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/deadloct/bitheroes-hg-bot/data"
+	"github.com/deadloct/bitheroes-hg-bot/game"
+	"github.com/deadloct/bitheroes-hg-bot/lib"
+	"github.com/deadloct/bitheroes-hg-bot/settings"
+	log "github.com/sirupsen/logrus"
+)
+
+func main() {
+	seed := flag.Uint64("seed", 0, "revealed game seed")
+	commitment := flag.String("commitment", "", "seed commitment published in the intro (optional)")
+	entrants := flag.String("entrants", game.EntrantsFileName, "entrant list attached to the reveal")
+	entrantsHash := flag.String("entrants-hash", "", "entrant list hash published in the reveal (optional)")
+	victors := flag.Int("victors", settings.DefaultVictorCount, "number of victors")
+	clone := flag.Int("clone", settings.DefaultClone, "clone multiplier")
+	prizes := flag.Int("prizes", 0, "number of prizes")
+	pacing := flag.String("pacing", game.PacingClassic, "pacing strategy")
+	weights := flag.String("weights", "", "survival weights as user=weight pairs separated by commas (optional)")
+	flag.Parse()
+
+	log.SetLevel(log.WarnLevel)
+
+	pacingStrategy, err := game.ParsePacing(*pacing)
+	if err != nil {
+		fail("invalid pacing: %v", err)
+	}
+
+	survivalWeights, err := game.ParseWeights(*weights)
+	if err != nil {
+		fail("invalid weights: %v", err)
+	}
+
+	if *commitment != "" {
+		if actual := lib.SeedCommitment(*seed); actual != *commitment {
+			fail("seed does not match commitment: expected %v, computed %v", *commitment, actual)
+		}
+		fmt.Println("seed matches commitment")
+	}
+
+	file, err := os.ReadFile(*entrants)
+	if err != nil {
+		fail("unable to read entrants: %v", err)
+	}
+
+	if *entrantsHash != "" {
+		if actual := lib.SHA256Hex(file); actual != *entrantsHash {
+			fail("entrant list does not match hash: expected %v, computed %v", *entrantsHash, actual)
+		}
+		fmt.Println("entrant list matches hash")
+	}
+
+	g := game.Replay(game.ReplayConfig{
+		Clone:       *clone,
+		EntrantIDs:  game.ParseEntrantsFile(file),
+		PhraseData:  data.PhrasesJSON,
+		PrizeCount:  *prizes,
+		Seed:        *seed,
+		VictorCount: *victors,
+		Pacing:      pacingStrategy,
+		Weights:     survivalWeights,
+	})
+
+	fmt.Println("standings:")
+	for _, s := range g.Standings() {
+		for _, p := range s.Participants {
+			fmt.Printf("%v: %v (<@%v>)\n", lib.Ordinal(s.Place), p.DisplayName(), p.User.ID)
+		}
+	}
+
+	if awards := g.Awards(); len(awards) > 0 {
+		fmt.Println("prizes:")
+		for _, a := range awards {
+			fmt.Printf("%v: <@%v>\n", a.PrizeName(), a.Participant.User.ID)
+		}
+	}
+}
+
+func fail(msg string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, msg+"\n", args...)
+	os.Exit(1)
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/deadloct/bitheroes-hg-bot/lib"
+	"github.com/deadloct/bitheroes-hg-bot/settings"
+	"github.com/deadloct/bitheroes-hg-bot/storage"
+	log "github.com/sirupsen/logrus"
+)
+
+func (m *Manager) handleHistory(session *discordgo.Session, ic *discordgo.InteractionCreate) {
+	page := 1
+	var gameID string
+
+	for _, option := range ic.ApplicationCommandData().Options {
+		switch option.Name {
+		case CommandHistoryOptionPage:
+			page = int(option.IntValue())
+		case CommandHistoryOptionGame:
+			gameID = strings.TrimSpace(option.StringValue())
+		}
+	}
+
+	if gameID != "" {
+		rec, err := m.store.GetGame(ic.GuildID, gameID)
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{
+				Content: fmt.Sprintf("The Capitol has no record of a game with ID %v in this server.", gameID),
+			})
+		case err != nil:
+			log.Errorf("could not retrieve game %v in guild %v: %v", gameID, ic.GuildID, err)
+			m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: "The Capitol archives are unavailable right now."})
+		default:
+			m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{
+				Embeds: []*discordgo.MessageEmbed{historyDetailEmbed(rec)},
+			})
+		}
+
+		return
+	}
+
+	offset := (page - 1) * settings.HistoryPageSize
+	recs, total, err := m.store.ListGames(ic.GuildID, offset, settings.HistoryPageSize)
+	if err != nil {
+		log.Errorf("could not list games in guild %v: %v", ic.GuildID, err)
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: "The Capitol archives are unavailable right now."})
+		return
+	}
+
+	if total == 0 {
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: "No Hunger Games have been recorded in this server yet."})
+		return
+	}
+
+	m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{historyListEmbed(recs, page, total)},
+	})
+}
+
+func historyListEmbed(recs []*storage.GameRecord, page, total int) *discordgo.MessageEmbed {
+	pages := (total + settings.HistoryPageSize - 1) / settings.HistoryPageSize
+
+	var lines []string
+	for _, rec := range recs {
+		lines = append(lines, fmt.Sprintf(
+			"`%v` <t:%v:f> — sponsored by **%v**, %v tributes, %v",
+			rec.ID, rec.CreatedAt.Unix(), rec.Sponsor, len(rec.Entrants), historyOutcome(rec),
+		))
+	}
+
+	if len(lines) == 0 {
+		lines = append(lines, "There are no games on this page.")
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       "Hunger Games History",
+		Description: lib.Truncate(strings.Join(lines, "\n"), settings.MaxEmbedLen),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Page %v of %v (%v games). Use the game option to see the details of one.", page, pages, total),
+		},
+	}
+}
+
+func historyDetailEmbed(rec *storage.GameRecord) *discordgo.MessageEmbed {
+	lines := []string{
+		fmt.Sprintf("**Sponsor:** %v", rec.Sponsor),
+		fmt.Sprintf("**Started by:** %v", rec.StartedBy.Name),
+		fmt.Sprintf("**Channel:** <#%v>", rec.ChannelID),
+		fmt.Sprintf("**Created:** <t:%v:f>", rec.CreatedAt.Unix()),
+		fmt.Sprintf("**Ran:** <t:%v:T> – <t:%v:T>", rec.StartedAt.Unix(), rec.FinishedAt.Unix()),
+		fmt.Sprintf("**Result:** %v", historyOutcome(rec)),
+		fmt.Sprintf("**Prizes:** %v", historyAwards(rec)),
+		fmt.Sprintf("**Victors wanted:** %v, **Clones:** %v, **Seed:** `%v`", rec.Config.VictorCount, rec.Config.Clone, rec.Config.Seed),
+		settings.WhiteSpaceChar,
+		fmt.Sprintf("**Tributes (%v):** %v", len(rec.Entrants), strings.Join(playerNames(rec.Entrants), ", ")),
+		settings.WhiteSpaceChar,
+		"**Eliminations:**",
+	}
+
+	for _, e := range rec.Eliminations {
+		lines = append(lines, fmt.Sprintf("Day %v: %v", e.Day+1, e.Phrase))
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("Hunger Games %v", rec.ID),
+		Description: lib.Truncate(strings.Join(lines, "\n"), settings.MaxEmbedLen),
+	}
+}
+
+func historyOutcome(rec *storage.GameRecord) string {
+	if rec.State == storage.GameCancelled {
+		return "cancelled"
+	}
+
+	return "won by " + strings.Join(playerNames(rec.CurrentVictors()), ", ")
+}
+
+func playerNames(players []storage.PlayerRecord) []string {
+	var names []string
+	for _, p := range players {
+		names = append(names, p.Name)
+	}
+
+	return names
+}
+
+func historyAwards(rec *storage.GameRecord) string {
+	if len(rec.Awards) == 0 {
+		return "none"
+	}
+
+	var awards []string
+	for _, a := range rec.Awards {
+		award := fmt.Sprintf("%v to %v", a.Prize, a.Player.Name)
+		if a.Status != "" {
+			award = fmt.Sprintf("%v (%v)", award, a.Status)
+		}
+
+		awards = append(awards, award)
+	}
+
+	return strings.Join(awards, ", ")
+}
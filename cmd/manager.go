@@ -3,33 +3,111 @@ package cmd
 import (
 	"fmt"
 	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/deadloct/bitheroes-hg-bot/game"
 	"github.com/deadloct/bitheroes-hg-bot/lib"
 	"github.com/deadloct/bitheroes-hg-bot/settings"
+	"github.com/deadloct/bitheroes-hg-bot/storage"
 	log "github.com/sirupsen/logrus"
 )
 
 const (
-	CommandPrefix                 = "hg-"
-	CommandHelp                   = CommandPrefix + "help"
-	CommandStart                  = CommandPrefix + "start"
-	CommandStartOptionClone       = "clone"
-	CommandStartOptionNotify      = "notify"
-	CommandStartOptionMinimumTier = "minimum-tier"
-	CommandStartOptionSponsor     = "sponsor"
-	CommandStartOptionStartDelay  = "start-delay-minutes"
-	CommandStartOptionVictorCount = "victors"
-	CommandCancel                 = CommandPrefix + "cancel"
-	CommandClear                  = CommandPrefix + "clear"
+	CommandPrefix                       = "hg-"
+	CommandHelp                         = CommandPrefix + "help"
+	CommandStart                        = CommandPrefix + "start"
+	CommandStartOptionClone             = "clone"
+	CommandStartOptionNotify            = "notify"
+	CommandStartOptionPing              = "ping"
+	CommandStartOptionEntry             = "entry"
+	CommandStartOptionRequiredRole      = "required-role"
+	CommandStartOptionExcludedRole      = "excluded-role"
+	CommandStartOptionAccountAge        = "min-account-days"
+	CommandStartOptionMemberAge         = "min-member-days"
+	CommandStartOptionPacing            = "pacing"
+	CommandStartOptionFinishDays        = "finish-days"
+	CommandStartOptionDuration          = "duration-minutes"
+	CommandStartOptionSpeed             = "speed"
+	CommandStartOptionDayDelay          = "day-delay-seconds"
+	CommandStartOptionReminders         = "reminders"
+	CommandStartOptionMinimumTier       = "minimum-tier"
+	CommandStartOptionFair              = "provably-fair"
+	CommandStartOptionPrizes            = "prizes"
+	CommandStartOptionClaim             = "claim-minutes"
+	CommandStartOptionSponsor           = "sponsor"
+	CommandStartOptionStartDelay        = "start-delay-minutes"
+	CommandStartOptionVictorCount       = "victors"
+	CommandCancel                       = CommandPrefix + "cancel"
+	CommandBeginNow                     = CommandPrefix + "begin-now"
+	CommandExtend                       = CommandPrefix + "extend"
+	CommandShorten                      = CommandPrefix + "shorten"
+	CommandScheduleOptionMinutes        = "minutes"
+	CommandPause                        = CommandPrefix + "pause"
+	CommandResume                       = CommandPrefix + "resume"
+	CommandClear                        = CommandPrefix + "clear"
+	CommandHistory                      = CommandPrefix + "history"
+	CommandHistoryOptionPage            = "page"
+	CommandHistoryOptionGame            = "game"
+	CommandStats                        = CommandPrefix + "stats"
+	CommandStatsOptionUser              = "user"
+	CommandLeaderboard                  = CommandPrefix + "leaderboard"
+	CommandLeaderboardOptionSort        = "sort"
+	CommandReroll                       = CommandPrefix + "reroll"
+	CommandRerollOptionUser             = "user"
+	CommandStatus                       = CommandPrefix + "status"
+	CommandParticipants                 = CommandPrefix + "participants"
+	CommandParticipantsOptionExport     = "export"
+	CommandSubscribe                    = CommandPrefix + "subscribe"
+	CommandUnsubscribe                  = CommandPrefix + "unsubscribe"
+	CommandConfig                       = CommandPrefix + "config"
+	CommandConfigShow                   = "show"
+	CommandConfigSet                    = "set"
+	CommandConfigEmoji                  = "emoji"
+	CommandConfigReset                  = "reset"
+	CommandConfigTierRole               = "tier-role"
+	CommandConfigOptionTier             = "tier"
+	CommandConfigTierRoles              = "tier-roles"
+	CommandConfigPermissions            = "permissions"
+	CommandConfigPermissionsAllow       = "allow"
+	CommandConfigPermissionsRevoke      = "revoke"
+	CommandConfigWinners                = "winners"
+	CommandConfigOptionCooldownDays     = "cooldown-days"
+	CommandConfigOptionCooldownGames    = "cooldown-games"
+	CommandConfigOptionHandicapDays     = "handicap-days"
+	CommandConfigOptionHandicapPercent  = "handicap-percent"
+	CommandConfigWeights                = "weights"
+	CommandConfigOptionBoosterPercent   = "booster-percent"
+	CommandConfigOptionPityLosses       = "pity-losses"
+	CommandConfigOptionPityPercent      = "pity-percent"
+	CommandConfigOptionRolePercent      = "role-percent"
+	CommandConfigOptionNotificationRole = "notification-role"
+	CommandConfigOptionStartDelay       = CommandStartOptionStartDelay
+	CommandConfigOptionVictors          = CommandStartOptionVictorCount
+	CommandConfigOptionLocale           = "locale"
+	CommandConfigOptionEmojiName        = "name"
+	CommandConfigOptionEmoji            = "emoji"
+	CommandConfigOptionSetting          = "setting"
+	CommandConfigOptionAction           = "command"
+	CommandConfigOptionRole             = "role"
+	CommandConfigOptionUser             = "user"
 )
 
 var (
 	nonAlphanumericRegex = regexp.MustCompile(`[^\p{L}\p{N}-_\.\[\] ]+`)
 
 	CommandStartOptionMinimumTierMinValue float64 = 2
+	CommandStartOptionDaysMinValue        float64 = 1
+	CommandStartOptionFinishDaysMaxValue  float64 = settings.MaximumFinishDays
+	CommandConfigOptionTierMinValue       float64 = 0
+	CommandConfigOptionWinnersMinValue    float64 = 0
+	CommandConfigOptionPercentMinValue    float64 = 1
+	CommandConfigOptionPercentMaxValue    float64 = 100
+	CommandConfigOptionWeightMaxValue     float64 = 500
+	CommandHistoryOptionPageMinValue      float64 = 1
+	CommandScheduleOptionMinutesMinValue  float64 = 1
 )
 
 var commands = []*discordgo.ApplicationCommand{
@@ -80,16 +158,423 @@ var commands = []*discordgo.ApplicationCommand{
 				Required:    false,
 				MinValue:    &CommandStartOptionMinimumTierMinValue,
 			},
+			{
+				Type:        discordgo.ApplicationCommandOptionBoolean,
+				Name:        CommandStartOptionFair,
+				Description: "Publish a seed commitment so anyone can verify the result afterward. Default: false",
+				Required:    false,
+			},
+			{
+				Type: discordgo.ApplicationCommandOptionString,
+				Name: CommandStartOptionPrizes,
+				Description: fmt.Sprintf(
+					"Prizes for 1st, 2nd, 3rd... place separated by semicolons. Max: %v",
+					settings.MaximumPrizes),
+				Required: false,
+			},
+			{
+				Type: discordgo.ApplicationCommandOptionInteger,
+				Name: CommandStartOptionClaim,
+				Description: fmt.Sprintf(
+					"Minutes winners have to claim their prize before it's rerolled. Default: no claiming, Min: %v, Max: %v",
+					settings.MinimumClaimMinutes, settings.MaximumClaimMinutes),
+				Required: false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionBoolean,
+				Name:        CommandStartOptionPing,
+				Description: "Ping the server's Hunger Games role. Turn off for small or test events. Default: true",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        CommandStartOptionEntry,
+				Description: "How tributes enter: reacting to the intro or pressing its buttons. Default: reactions",
+				Required:    false,
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{Name: string(game.EntryReactions), Value: string(game.EntryReactions)},
+					{Name: string(game.EntryButtons), Value: string(game.EntryButtons)},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionRole,
+				Name:        CommandStartOptionRequiredRole,
+				Description: "Only members with this role may enter",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionRole,
+				Name:        CommandStartOptionExcludedRole,
+				Description: "Members with this role may not enter",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				Name:        CommandStartOptionAccountAge,
+				Description: "Minimum age in days of an entrant's Discord account",
+				Required:    false,
+				MinValue:    &CommandStartOptionDaysMinValue,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				Name:        CommandStartOptionMemberAge,
+				Description: "Minimum days since an entrant joined this server",
+				Required:    false,
+				MinValue:    &CommandStartOptionDaysMinValue,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        CommandStartOptionPacing,
+				Description: "How quickly tributes die. Default: classic",
+				Required:    false,
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{Name: game.PacingClassic, Value: game.PacingClassic},
+					{Name: game.PacingSlowBurn, Value: game.PacingSlowBurn},
+					{Name: game.PacingBloodbath, Value: game.PacingBloodbath},
+					{Name: game.PacingFinishIn, Value: game.PacingFinishIn},
+				},
+			},
+			{
+				Type: discordgo.ApplicationCommandOptionInteger,
+				Name: CommandStartOptionFinishDays,
+				Description: fmt.Sprintf(
+					"Days the finish-in pacing crowns the victors within. Default: %v, Max: %v",
+					settings.DefaultFinishDays, settings.MaximumFinishDays),
+				Required: false,
+				MinValue: &CommandStartOptionDaysMinValue,
+				MaxValue: CommandStartOptionFinishDaysMaxValue,
+			},
+			{
+				Type: discordgo.ApplicationCommandOptionString,
+				Name: CommandStartOptionReminders,
+				Description: fmt.Sprintf(
+					"Minutes before entries close to send reminders, like 10,5,1. 0 for none. Default: %v",
+					settings.DefaultReminder.Minutes()),
+				Required: false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        CommandStartOptionSpeed,
+				Description: "How long each day lasts: instant, fast, normal or dramatic. Default: normal",
+				Required:    false,
+				Choices:     speedChoices(),
+			},
+			{
+				Type: discordgo.ApplicationCommandOptionNumber,
+				Name: CommandStartOptionDayDelay,
+				Description: fmt.Sprintf(
+					"Seconds between days instead of a speed. Min: %v, Max: %v",
+					settings.MinimumDayDelaySeconds, settings.MaximumDayDelaySeconds),
+				Required: false,
+			},
+			{
+				Type: discordgo.ApplicationCommandOptionInteger,
+				Name: CommandStartOptionDuration,
+				Description: fmt.Sprintf(
+					"About how long the game runs once entries close. Min: %v, Max: %v",
+					settings.MinimumTargetDuration, settings.MaximumTargetDuration),
+				Required: false,
+			},
 		},
 	},
 	{
 		Name:        CommandCancel,
 		Description: "Cancel the active Hunger Games event in this channel",
 	},
+	{
+		Name:        CommandBeginNow,
+		Description: "Close entries and start this channel's Hunger Games right away",
+	},
+	{
+		Name:        CommandExtend,
+		Description: "Keep entries for this channel's Hunger Games open longer",
+		Options:     scheduleOptions("Minutes to add"),
+	},
+	{
+		Name:        CommandShorten,
+		Description: "Close entries for this channel's Hunger Games sooner",
+		Options:     scheduleOptions("Minutes to take away"),
+	},
+	{
+		Name:        CommandPause,
+		Description: "Pause this channel's Hunger Games before its next day",
+	},
+	{
+		Name:        CommandResume,
+		Description: "Resume this channel's paused Hunger Games",
+	},
 	{
 		Name:        CommandClear,
 		Description: "Clear bot messages in this channel",
 	},
+	{
+		Name:        CommandHistory,
+		Description: "Browse this server's past Hunger Games events",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				Name:        CommandHistoryOptionPage,
+				Description: "Page of past games to show, newest first. Default: 1",
+				Required:    false,
+				MinValue:    &CommandHistoryOptionPageMinValue,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        CommandHistoryOptionGame,
+				Description: "ID of a game to show in detail",
+				Required:    false,
+			},
+		},
+	},
+	{
+		Name:        CommandStats,
+		Description: "Shows a player's Hunger Games record in this server",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Name:        CommandStatsOptionUser,
+				Description: "Player to look up. Default: you",
+				Required:    false,
+			},
+		},
+	},
+	{
+		Name:        CommandLeaderboard,
+		Description: "Shows this server's top Hunger Games players",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        CommandLeaderboardOptionSort,
+				Description: "How to rank players. Default: wins",
+				Required:    false,
+				Choices:     leaderboardSortChoices(),
+			},
+		},
+	},
+	{
+		Name:        CommandReroll,
+		Description: "Replace a victor of the last Hunger Games in this channel with the next best tribute",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Name:        CommandRerollOptionUser,
+				Description: "Victor to replace",
+				Required:    true,
+			},
+		},
+	},
+	{
+		Name:        CommandStatus,
+		Description: "Shows the state of the Hunger Games in this channel",
+	},
+	{
+		Name:        CommandParticipants,
+		Description: "Lists the tributes who have entered the Hunger Games in this channel",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionBoolean,
+				Name:        CommandParticipantsOptionExport,
+				Description: "Attach the full list as a file. Only for the starter and administrators",
+				Required:    false,
+			},
+		},
+	},
+	{
+		Name:        CommandSubscribe,
+		Description: "Get pinged whenever a Hunger Games begins in this server",
+	},
+	{
+		Name:        CommandUnsubscribe,
+		Description: "Stop getting pinged when a Hunger Games begins in this server",
+	},
+	{
+		Name:        CommandConfig,
+		Description: "View and change this server's Hunger Games settings",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        CommandConfigShow,
+				Description: "Shows this server's settings",
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        CommandConfigSet,
+				Description: "Changes this server's defaults",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionRole,
+						Name:        CommandConfigOptionNotificationRole,
+						Description: "Role pinged when a game starts",
+						Required:    false,
+					},
+					{
+						Type: discordgo.ApplicationCommandOptionNumber,
+						Name: CommandConfigOptionStartDelay,
+						Description: fmt.Sprintf(
+							"Default minutes to wait for reactions. Min: %v, Max: %v",
+							settings.MinimumStartDelay, settings.MaximumStartDelay),
+						Required: false,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        CommandConfigOptionVictors,
+						Description: "Default number of victors",
+						Required:    false,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        CommandConfigOptionLocale,
+						Description: "Language of the intro and help, like en",
+						Required:    false,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        CommandConfigEmoji,
+				Description: "Replaces one of the bot's emojis with one from this server",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        CommandConfigOptionEmojiName,
+						Description: "Emoji to replace",
+						Required:    true,
+						Choices:     emojiChoices(),
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        CommandConfigOptionEmoji,
+						Description: "Custom emoji to use instead",
+						Required:    true,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        CommandConfigTierRole,
+				Description: "Sets the Bit Heroes tier a role proves, so minimum-tier is enforced",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionRole,
+						Name:        CommandConfigOptionRole,
+						Description: "Role held by players of the tier",
+						Required:    true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        CommandConfigOptionTier,
+						Description: "Tier the role proves, or 0 to remove the role",
+						Required:    true,
+						MinValue:    &CommandConfigOptionTierMinValue,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        CommandConfigWinners,
+				Description: "Keeps recent victors out of new games or lowers their odds",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        CommandConfigOptionCooldownDays,
+						Description: "Victors can't enter for this many days after winning, 0 to turn off",
+						MinValue:    &CommandConfigOptionWinnersMinValue,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        CommandConfigOptionCooldownGames,
+						Description: "Victors can't enter the next this many games, 0 to turn off",
+						MinValue:    &CommandConfigOptionWinnersMinValue,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        CommandConfigOptionHandicapDays,
+						Description: "Victors of this many days have lower odds of surviving, 0 to turn off",
+						MinValue:    &CommandConfigOptionWinnersMinValue,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        CommandConfigOptionHandicapPercent,
+						Description: "Percentage of the usual survival odds handicapped victors get",
+						MinValue:    &CommandConfigOptionPercentMinValue,
+						MaxValue:    CommandConfigOptionPercentMaxValue,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        CommandConfigWeights,
+				Description: "Changes the odds of surviving each day, in percent of the usual odds",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        CommandConfigOptionBoosterPercent,
+						Description: "Survival odds of server boosters, like 110, or 0 to turn off",
+						MinValue:    &CommandConfigOptionWinnersMinValue,
+						MaxValue:    CommandConfigOptionWeightMaxValue,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        CommandConfigOptionPityLosses,
+						Description: "Games lost in a row before pity-percent applies, 0 to turn off",
+						MinValue:    &CommandConfigOptionWinnersMinValue,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        CommandConfigOptionPityPercent,
+						Description: "Survival odds of tributes on a losing streak, like 125",
+						MinValue:    &CommandConfigOptionWinnersMinValue,
+						MaxValue:    CommandConfigOptionWeightMaxValue,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionRole,
+						Name:        CommandConfigOptionRole,
+						Description: "Role whose survival odds role-percent sets",
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        CommandConfigOptionRolePercent,
+						Description: "Survival odds of the role, or 0 to remove it",
+						MinValue:    &CommandConfigOptionWinnersMinValue,
+						MaxValue:    CommandConfigOptionWeightMaxValue,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        CommandConfigReset,
+				Description: "Restores a setting to the bot's default",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        CommandConfigOptionSetting,
+						Description: "Setting to restore",
+						Required:    true,
+						Choices:     configSettingChoices(),
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+				Name:        CommandConfigPermissions,
+				Description: "Chooses who may start, cancel, and clear Hunger Games",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        CommandConfigPermissionsAllow,
+						Description: "Allows a role or user to run a command, replacing its default",
+						Options:     permissionOptions(),
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        CommandConfigPermissionsRevoke,
+						Description: "Stops a role or user from running a command. Revoking everybody restores the default",
+						Options:     permissionOptions(),
+					},
+				},
+			},
+		},
+	},
 }
 
 func init() {
@@ -113,10 +598,11 @@ func init() {
 type Manager struct {
 	phraseData []byte
 	jokeData   []byte
+	store      storage.Store
 }
 
-func NewManager(phraseData []byte, jokeData []byte) *Manager {
-	return &Manager{phraseData: phraseData, jokeData: jokeData}
+func NewManager(phraseData []byte, jokeData []byte, store storage.Store) *Manager {
+	return &Manager{phraseData: phraseData, jokeData: jokeData, store: store}
 }
 
 func (m *Manager) RegisterCommands(session *discordgo.Session) error {
@@ -159,6 +645,11 @@ func (m *Manager) DeregisterCommmands(session *discordgo.Session) error {
 }
 
 func (m *Manager) CommandHandler(session *discordgo.Session, ic *discordgo.InteractionCreate) {
+	// Game buttons are handled by the game manager and the rest by ComponentHandler.
+	if ic.Type != discordgo.InteractionApplicationCommand {
+		return
+	}
+
 	if ic.Member == nil {
 		log.Infof("user attempted to run the bot from outside a channel: %v", ic.User.ID)
 		content := "Citizens must sponsor a new Hunger Games from a channel."
@@ -175,6 +666,48 @@ func (m *Manager) CommandHandler(session *discordgo.Session, ic *discordgo.Inter
 		return
 	}
 
+	// These commands reply privately and skip the public acknowledgement.
+	switch ic.ApplicationCommandData().Name {
+	case CommandHistory:
+		m.handleHistory(session, ic)
+		return
+	case CommandStats:
+		m.handleStats(session, ic)
+		return
+	case CommandLeaderboard:
+		m.handleLeaderboard(session, ic)
+		return
+	case CommandReroll:
+		m.handleReroll(session, ic)
+		return
+	case CommandConfig:
+		m.handleConfig(session, ic)
+		return
+	case CommandStatus:
+		m.handleStatus(session, ic)
+		return
+	case CommandParticipants:
+		m.handleParticipants(session, ic)
+		return
+	case CommandBeginNow, CommandExtend, CommandShorten:
+		m.handleSchedule(session, ic)
+		return
+	case CommandPause, CommandResume:
+		m.handlePause(session, ic)
+		return
+	case CommandSubscribe:
+		m.handleSubscription(session, ic, true)
+		return
+	case CommandUnsubscribe:
+		m.handleSubscription(session, ic, false)
+		return
+	}
+
+	gs := m.guildSettings(ic.GuildID)
+	if action, ok := commandActions[ic.ApplicationCommandData().Name]; ok && !m.checkPermission(session, ic, gs, action) {
+		return
+	}
+
 	session.InteractionRespond(ic.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{Content: "> Command acknowledged. Engaging the capitol of Panem."},
@@ -198,15 +731,36 @@ func (m *Manager) CommandHandler(session *discordgo.Session, ic *discordgo.Inter
 	v := ic.ApplicationCommandData().Name
 	switch v {
 	case CommandHelp:
-		session.ChannelMessageSend(ic.ChannelID, settings.Help)
+		// The help text is longer than a single Discord message, so let the sender split it.
+		game.NewDiscordSender(session, ic.ChannelID).Send(settings.Help(gs.Locale))
 
 	case CommandStart:
 		var minimumTier int
 		var notify *discordgo.User
+		var provablyFair bool
+		var prizes []string
+		var claimDeadline time.Duration
+		var pacing string
+		var finishDays int
+		var targetDuration time.Duration
+		var dayDelay time.Duration
+		var customDayDelay bool
+		reminders := []time.Duration{settings.DefaultReminder}
+		ping := true
+		entryMode := game.EntryReactions
+		eligibility := game.Eligibility{TierRoles: gs.TierRoles}
 
 		delay := settings.DefaultStartDelay * time.Minute
+		if gs.StartDelay > 0 {
+			delay = gs.StartDelay
+		}
+
 		clone := settings.DefaultClone
 		victors := settings.DefaultVictorCount
+		if gs.VictorCount > 0 {
+			victors = gs.VictorCount
+		}
+
 		sponsor := startedBy.DisplayName()
 
 		for _, option := range options {
@@ -272,6 +826,113 @@ func (m *Manager) CommandHandler(session *discordgo.Session, ic *discordgo.Inter
 				if v > 1 {
 					minimumTier = v
 				}
+
+			case CommandStartOptionFair:
+				provablyFair = option.BoolValue()
+
+			case CommandStartOptionPing:
+				ping = option.BoolValue()
+
+			case CommandStartOptionEntry:
+				entryMode = game.EntryMode(option.StringValue())
+
+			case CommandStartOptionRequiredRole:
+				eligibility.RequiredRoles = []string{option.RoleValue(nil, "").ID}
+
+			case CommandStartOptionExcludedRole:
+				eligibility.ExcludedRoles = []string{option.RoleValue(nil, "").ID}
+
+			case CommandStartOptionAccountAge:
+				eligibility.MinAccountAge = time.Duration(option.IntValue()) * 24 * time.Hour
+
+			case CommandStartOptionMemberAge:
+				eligibility.MinMembership = time.Duration(option.IntValue()) * 24 * time.Hour
+
+			case CommandStartOptionPacing:
+				pacing = option.StringValue()
+
+			case CommandStartOptionFinishDays:
+				finishDays = int(option.IntValue())
+
+			case CommandStartOptionReminders:
+				v, err := parseReminders(option.StringValue())
+				if err != nil {
+					msg := fmt.Sprintf("> The Capitol can't read those reminders: %v. Sending the default reminder instead.", err)
+					session.ChannelMessageSend(ic.ChannelID, msg)
+					log.Warn(msg)
+				} else {
+					reminders = v
+				}
+
+			case CommandStartOptionSpeed:
+				if v, ok := settings.SpeedDayDelay(option.StringValue()); ok && !customDayDelay {
+					dayDelay = v
+				}
+
+			case CommandStartOptionDayDelay:
+				v := option.FloatValue()
+				customDayDelay = true
+				switch {
+				case v < settings.MinimumDayDelaySeconds:
+					dayDelay = time.Duration(settings.MinimumDayDelaySeconds * float64(time.Second))
+					msg := fmt.Sprintf("> A day of %v seconds is much too short. Each day will last %v instead.", v, dayDelay)
+					session.ChannelMessageSend(ic.ChannelID, msg)
+					log.Warn(msg)
+				case v > settings.MaximumDayDelaySeconds:
+					dayDelay = settings.MaximumDayDelay
+					msg := fmt.Sprintf("> A day of %v seconds is much too long. Each day will last %v instead.", v, dayDelay)
+					session.ChannelMessageSend(ic.ChannelID, msg)
+					log.Warn(msg)
+				default:
+					dayDelay = time.Duration(v * float64(time.Second))
+				}
+
+			case CommandStartOptionDuration:
+				v := int(option.IntValue())
+				switch {
+				case v < settings.MinimumTargetDuration:
+					targetDuration = settings.MinimumTargetDuration * time.Minute
+					msg := fmt.Sprintf("> A duration of %v minutes is much too short. The Games will last about %v instead.", v, targetDuration)
+					session.ChannelMessageSend(ic.ChannelID, msg)
+					log.Warn(msg)
+				case v > settings.MaximumTargetDuration:
+					targetDuration = settings.MaximumTargetDuration * time.Minute
+					msg := fmt.Sprintf("> A duration of %v minutes is much too long. The Games will last about %v instead.", v, targetDuration)
+					session.ChannelMessageSend(ic.ChannelID, msg)
+					log.Warn(msg)
+				default:
+					targetDuration = time.Duration(v) * time.Minute
+				}
+
+			case CommandStartOptionClaim:
+				v := int(option.IntValue())
+				switch {
+				case v < settings.MinimumClaimMinutes:
+					msg := fmt.Sprintf("> A claim window of %v minutes is much too short. Winners won't need to claim their prizes.", v)
+					session.ChannelMessageSend(ic.ChannelID, msg)
+					log.Warn(msg)
+				case v > settings.MaximumClaimMinutes:
+					claimDeadline = settings.MaximumClaimMinutes * time.Minute
+					msg := fmt.Sprintf("> A claim window of %v minutes is much too long. Setting to %v instead.", v, claimDeadline)
+					session.ChannelMessageSend(ic.ChannelID, msg)
+					log.Warn(msg)
+				default:
+					claimDeadline = time.Duration(v) * time.Minute
+				}
+
+			case CommandStartOptionPrizes:
+				for _, v := range strings.Split(option.StringValue(), ";") {
+					if v = strings.TrimSpace(m.sanitize(v)); v != "" {
+						prizes = append(prizes, v)
+					}
+				}
+
+				if len(prizes) > settings.MaximumPrizes {
+					msg := fmt.Sprintf("> %v prizes are too many for the Capitol to track. Only the first %v will be awarded.", len(prizes), settings.MaximumPrizes)
+					session.ChannelMessageSend(ic.ChannelID, msg)
+					log.Warn(msg)
+					prizes = prizes[:settings.MaximumPrizes]
+				}
 			}
 		}
 
@@ -280,26 +941,78 @@ func (m *Manager) CommandHandler(session *discordgo.Session, ic *discordgo.Inter
 			return
 		}
 
-		jp := lib.NewJSONPhrases(m.phraseData)
+		seed, err := lib.NewSeed()
+		if err != nil {
+			log.Errorf("unable to generate game seed: %v", err)
+			session.ChannelMessageSend(ic.ChannelID, "> The Gamemakers could not prepare the arena. Please try again.")
+			return
+		}
+
+		rng := lib.NewSeededRandomizer(seed)
+		jp := lib.NewJSONPhrases(m.phraseData, rng)
 		log.Infof("imported %v phrases", jp.PhraseCount())
 
-		jj, err := lib.NewJSONJokes(m.jokeData)
+		// Jokes are told on a timer during signup, so they get their own source
+		// to keep the game's seeded sequence replayable.
+		jj, err := lib.NewJSONJokes(m.jokeData, nil)
 		if err != nil {
 			log.Warnf("unable to load jokes: %v", err)
 		}
 
+		// finish-days alone is enough to pick the finish-in pacing.
+		if finishDays > 0 && (pacing == "" || pacing == game.PacingFinishIn) {
+			pacing = fmt.Sprintf("%v-%v", game.PacingFinishIn, finishDays)
+		} else if pacing == game.PacingFinishIn {
+			pacing = fmt.Sprintf("%v-%v", game.PacingFinishIn, settings.DefaultFinishDays)
+		}
+
+		// Without a pacing the game uses the classic one, or plans its own for a target duration.
+		var pacingStrategy game.PacingStrategy
+		if pacing != "" {
+			if pacingStrategy, err = game.ParsePacing(pacing); err != nil {
+				log.Warnf("unknown pacing %q, using classic: %v", pacing, err)
+				pacingStrategy = nil
+			}
+		}
+
+		history := m.guildHistory(gs)
+		winners := winnerPolicy(gs, history)
+		weighers := survivalWeighers(gs, history)
+
+		var notifyRole string
+		if ping {
+			notifyRole = gs.NotificationRole
+		}
+
 		cfg := game.GameStartConfig{
 			Guild:           guild,
 			Channel:         channel,
+			ClaimDeadline:   claimDeadline,
+			DayDelay:        dayDelay,
 			Delay:           delay,
 			Clone:           clone,
+			Emojis:          guildEmojis(gs),
+			Eligibility:     eligibility,
+			EntryMode:       entryMode,
+			Locale:          gs.Locale,
 			MinimumTier:     minimumTier,
 			Notify:          notify,
+			NotifyRole:      notifyRole,
+			Pacing:          pacingStrategy,
 			JokeGenerator:   jj,
 			PhraseGenerator: jp,
+			Prizes:          prizes,
+			ProvablyFair:    provablyFair,
+			Randomizer:      rng,
+			Reminders:       reminders,
+			Seed:            seed,
 			Sponsor:         sponsor,
 			StartedBy:       startedBy,
+			Store:           m.store,
+			TargetDuration:  targetDuration,
 			VictorCount:     victors,
+			Weighers:        weighers,
+			Winners:         winners,
 		}
 
 		if err := game.ManagerInstance(session).StartGame(cfg); err != nil {
@@ -318,3 +1031,50 @@ func (m *Manager) CommandHandler(session *discordgo.Session, ic *discordgo.Inter
 func (m *Manager) sanitize(str string) string {
 	return nonAlphanumericRegex.ReplaceAllString(str, "")
 }
+
+func (m *Manager) respondEphemeral(session *discordgo.Session, ic *discordgo.InteractionCreate, data *discordgo.InteractionResponseData) {
+	data.Flags |= discordgo.MessageFlagsEphemeral
+	err := session.InteractionRespond(ic.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: data,
+	})
+
+	if err != nil {
+		log.Errorf("error responding to interaction %v: %v", ic.ID, err)
+	}
+}
+
+func speedChoices() []*discordgo.ApplicationCommandOptionChoice {
+	var choices []*discordgo.ApplicationCommandOptionChoice
+	for _, p := range settings.SpeedPresets {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: fmt.Sprintf("%v (%v)", p.Name, p.DayDelay), Value: p.Name})
+	}
+
+	return choices
+}
+
+// parseReminders reads minutes separated by commas, where 0 turns reminders off.
+func parseReminders(str string) ([]time.Duration, error) {
+	var reminders []time.Duration
+	for _, part := range strings.Split(str, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		v, err := strconv.ParseFloat(part, 64)
+		if err != nil || v < 0 {
+			return nil, fmt.Errorf("%q isn't a number of minutes", part)
+		}
+
+		if v > 0 {
+			reminders = append(reminders, time.Duration(v*float64(time.Minute)))
+		}
+	}
+
+	if len(reminders) > settings.MaximumReminders {
+		return nil, fmt.Errorf("at most %v reminders can be sent", settings.MaximumReminders)
+	}
+
+	return reminders, nil
+}
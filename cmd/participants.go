@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/deadloct/bitheroes-hg-bot/game"
+	"github.com/deadloct/bitheroes-hg-bot/settings"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	ParticipantsButtonPrefix = "hg-participants"
+	ParticipantsFileName     = "participants.txt"
+)
+
+// ComponentHandler handles the buttons of the bot's private replies. Buttons
+// of games are handled by game.Manager.
+func (m *Manager) ComponentHandler(session *discordgo.Session, ic *discordgo.InteractionCreate) {
+	if ic.Type != discordgo.InteractionMessageComponent {
+		return
+	}
+
+	customID := ic.MessageComponentData().CustomID
+	if page, ok := strings.CutPrefix(customID, ParticipantsButtonPrefix+":"); ok {
+		n, err := strconv.Atoi(page)
+		if err != nil {
+			log.Errorf("invalid participants button %v: %v", customID, err)
+			return
+		}
+
+		m.handleParticipantsPage(session, ic, n)
+	}
+}
+
+func (m *Manager) handleParticipants(session *discordgo.Session, ic *discordgo.InteractionCreate) {
+	var export bool
+	for _, option := range ic.ApplicationCommandData().Options {
+		if option.Name == CommandParticipantsOptionExport {
+			export = option.BoolValue()
+		}
+	}
+
+	g := game.ManagerInstance(session).ActiveGame(ic.ChannelID)
+	if g == nil {
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: "There is no Hunger Games in this channel right now."})
+		return
+	}
+
+	entrants := g.Entrants()
+	if !export {
+		m.respondEphemeral(session, ic, participantsPage(entrants, 1))
+		return
+	}
+
+	isStarter := g.StartedBy != nil && g.StartedBy.User != nil && g.StartedBy.User.ID == ic.Member.User.ID
+	if !isStarter && ic.Member.Permissions&discordgo.PermissionAdministrator == 0 {
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: "Only the Gamemaker who started this game or an administrator can export the tributes."})
+		return
+	}
+
+	log.Infof("%v exported the %v tributes of the game in channel %v", ic.Member.User.ID, len(entrants), ic.ChannelID)
+	m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{
+		Content: fmt.Sprintf("%v entered.", game.TributeCount(len(entrants))),
+		Files: []*discordgo.File{{
+			Name:        ParticipantsFileName,
+			ContentType: "text/plain",
+			Reader:      bytes.NewReader(participantsFile(entrants)),
+		}},
+	})
+}
+
+// handleParticipantsPage replaces the private list with another page.
+func (m *Manager) handleParticipantsPage(session *discordgo.Session, ic *discordgo.InteractionCreate, page int) {
+	data := &discordgo.InteractionResponseData{Content: "This Hunger Games is over.", Embeds: []*discordgo.MessageEmbed{}, Components: []discordgo.MessageComponent{}}
+	if g := game.ManagerInstance(session).ActiveGame(ic.ChannelID); g != nil {
+		data = participantsPage(g.Entrants(), page)
+	}
+
+	err := session.InteractionRespond(ic.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: data,
+	})
+	if err != nil {
+		log.Errorf("error responding to interaction %v: %v", ic.ID, err)
+	}
+}
+
+func participantsPage(entrants []*game.Participant, page int) *discordgo.InteractionResponseData {
+	if len(entrants) == 0 {
+		return &discordgo.InteractionResponseData{Content: "No tributes have entered yet."}
+	}
+
+	pages := (len(entrants) + settings.ParticipantsPerPage - 1) / settings.ParticipantsPerPage
+	page = max(1, min(page, pages))
+
+	var lines []string
+	start := (page - 1) * settings.ParticipantsPerPage
+	for i, p := range entrants[start:min(start+settings.ParticipantsPerPage, len(entrants))] {
+		lines = append(lines, fmt.Sprintf("%v. %v", start+i+1, p.DisplayName()))
+	}
+
+	data := &discordgo.InteractionResponseData{
+		Content: "",
+		Embeds: []*discordgo.MessageEmbed{{
+			Title:       fmt.Sprintf("Tributes (%v)", len(entrants)),
+			Description: strings.Join(lines, "\n"),
+			Footer:      &discordgo.MessageEmbedFooter{Text: fmt.Sprintf("Page %v of %v", page, pages)},
+		}},
+		Components: []discordgo.MessageComponent{},
+	}
+
+	if pages > 1 {
+		data.Components = []discordgo.MessageComponent{
+			discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "Previous",
+					Style:    discordgo.SecondaryButton,
+					CustomID: fmt.Sprintf("%v:%v", ParticipantsButtonPrefix, page-1),
+					Disabled: page == 1,
+				},
+				discordgo.Button{
+					Label:    "Next",
+					Style:    discordgo.SecondaryButton,
+					CustomID: fmt.Sprintf("%v:%v", ParticipantsButtonPrefix, page+1),
+					Disabled: page == pages,
+				},
+			}},
+		}
+	}
+
+	return data
+}
+
+// participantsFile lists one tribute per line with their user ID.
+func participantsFile(entrants []*game.Participant) []byte {
+	var buf bytes.Buffer
+	for _, p := range entrants {
+		fmt.Fprintf(&buf, "%v\t%v\n", p.DisplayFullName(), p.User.ID)
+	}
+
+	return buf.Bytes()
+}
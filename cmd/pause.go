@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/deadloct/bitheroes-hg-bot/game"
+	"github.com/deadloct/bitheroes-hg-bot/permissions"
+	log "github.com/sirupsen/logrus"
+)
+
+// handlePause suspends or resumes the channel's running game for /hg-pause and
+// /hg-resume. Whoever may cancel the game may pause it.
+func (m *Manager) handlePause(session *discordgo.Session, ic *discordgo.InteractionCreate) {
+	if !m.checkPermission(session, ic, m.guildSettings(ic.GuildID), permissions.ActionCancel) {
+		return
+	}
+
+	g := game.ManagerInstance(session).ActiveGame(ic.ChannelID)
+	if g == nil {
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: "There is no Hunger Games in this channel right now. Start one with `/hg-start`."})
+		return
+	}
+
+	pause := ic.ApplicationCommandData().Name == CommandPause
+	log.Infof("%v issued %v for game %v", ic.Member.User.ID, ic.ApplicationCommandData().Name, g.ID())
+
+	var err error
+	if pause {
+		err = g.Pause()
+	} else {
+		err = g.Resume()
+	}
+
+	switch {
+	case errors.Is(err, game.ErrNotInArena):
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: "The games haven't begun yet. Use `/hg-extend` to give tributes more time instead."})
+	case errors.Is(err, game.ErrNoDaysLeft):
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: "The final day has already begun, so there's nothing left to pause."})
+	case errors.Is(err, game.ErrAlreadyPaused):
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: "The arena is already paused. Use `/hg-resume` to continue."})
+	case errors.Is(err, game.ErrNotPaused):
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: "The arena isn't paused."})
+	case err != nil:
+		log.Errorf("could not pause or resume game %v: %v", g.ID(), err)
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: "The Gamemakers could not do that. Please try again."})
+	case pause:
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: "The arena will pause before the next day. Use `/hg-resume` to continue."})
+	default:
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: "The arena has resumed."})
+	}
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/deadloct/bitheroes-hg-bot/game"
+	"github.com/deadloct/bitheroes-hg-bot/permissions"
+	"github.com/deadloct/bitheroes-hg-bot/storage"
+	log "github.com/sirupsen/logrus"
+)
+
+// commandActions maps the commands that are subject to the guild's permission policy to their action.
+var commandActions = map[string]permissions.Action{
+	CommandStart:  permissions.ActionStart,
+	CommandCancel: permissions.ActionCancel,
+	CommandClear:  permissions.ActionClear,
+}
+
+func permissionActionChoices() []*discordgo.ApplicationCommandOptionChoice {
+	var choices []*discordgo.ApplicationCommandOptionChoice
+	for _, action := range permissions.Actions {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: string(action), Value: string(action)})
+	}
+
+	return choices
+}
+
+func permissionOptions() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        CommandConfigOptionAction,
+			Description: "Command to change",
+			Required:    true,
+			Choices:     permissionActionChoices(),
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionRole,
+			Name:        CommandConfigOptionRole,
+			Description: "Role to change",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionUser,
+			Name:        CommandConfigOptionUser,
+			Description: "User to change",
+			Required:    false,
+		},
+	}
+}
+
+// checkPermission tells the member privately when they may not run action.
+func (m *Manager) checkPermission(session *discordgo.Session, ic *discordgo.InteractionCreate, gs *storage.GuildSettings, action permissions.Action) bool {
+	check := permissions.Check{Member: ic.Member}
+	if g := game.ManagerInstance(session).ActiveGame(ic.ChannelID); g != nil && g.StartedBy != nil && g.StartedBy.User != nil {
+		check.StartedBy = g.StartedBy.User.ID
+	}
+
+	if permissions.Allowed(gs.Permissions, action, check) {
+		return true
+	}
+
+	log.Infof("user %v is not allowed to %v in guild %v", ic.Member.User.ID, action, ic.GuildID)
+	m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{
+		Content: fmt.Sprintf(
+			"The Capitol does not allow you to %v Hunger Games commands here. This is allowed for: %v.",
+			action, permissions.Describe(gs.Permissions, action),
+		),
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	})
+
+	return false
+}
+
+// changePermissions applies /hg-config permissions allow or revoke to gs.
+func (m *Manager) changePermissions(session *discordgo.Session, ic *discordgo.InteractionCreate, gs *storage.GuildSettings, sub *discordgo.ApplicationCommandInteractionDataOption) bool {
+	var action permissions.Action
+	var roleID, userID string
+	for _, option := range sub.Options {
+		switch option.Name {
+		case CommandConfigOptionAction:
+			action = permissions.Action(option.StringValue())
+		case CommandConfigOptionRole:
+			roleID = option.RoleValue(nil, "").ID
+		case CommandConfigOptionUser:
+			userID = option.UserValue(nil).ID
+		}
+	}
+
+	if !permissions.IsAction(string(action)) || (roleID == "" && userID == "") {
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: "Please choose a command and a role or user."})
+		return false
+	}
+
+	if sub.Name == CommandConfigPermissionsAllow {
+		gs.Permissions = permissions.Grant(gs.Permissions, action, roleID, userID)
+	} else {
+		gs.Permissions = permissions.Revoke(gs.Permissions, action, roleID, userID)
+	}
+
+	log.Infof("%v changed the %v permission in guild %v: %+v", ic.Member.User.ID, action, ic.GuildID, gs.Permissions[string(action)])
+	return true
+}
+
+func permissionLines(gs *storage.GuildSettings) string {
+	var lines []string
+	for _, action := range permissions.Actions {
+		lines = append(lines, fmt.Sprintf("• %v: %v", action, permissions.Describe(gs.Permissions, action)))
+	}
+
+	return strings.Join(lines, "\n")
+}
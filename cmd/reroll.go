@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/deadloct/bitheroes-hg-bot/game"
+	"github.com/deadloct/bitheroes-hg-bot/lib"
+	"github.com/deadloct/bitheroes-hg-bot/storage"
+	log "github.com/sirupsen/logrus"
+)
+
+func (m *Manager) handleReroll(session *discordgo.Session, ic *discordgo.InteractionCreate) {
+	var user *discordgo.User
+	for _, option := range ic.ApplicationCommandData().Options {
+		if option.Name == CommandRerollOptionUser {
+			user = option.UserValue(session)
+		}
+	}
+
+	if user == nil {
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: "Please choose the victor to replace."})
+		return
+	}
+
+	g := game.ManagerInstance(session).LastFinishedGame(ic.ChannelID)
+	if g == nil {
+		g = m.restoreLastGame(session, ic)
+	}
+
+	if g == nil {
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: "There is no finished Hunger Games in this channel to reroll."})
+		return
+	}
+
+	isStarter := g.StartedBy != nil && g.StartedBy.User != nil && g.StartedBy.User.ID == ic.Member.User.ID
+	if !isStarter && ic.Member.Permissions&discordgo.PermissionAdministrator == 0 {
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: "Only the Gamemaker who started the last game or an administrator can reroll a victor."})
+		return
+	}
+
+	log.Infof("%v is rerolling %v in game %v", ic.Member.User.ID, user.ID, g.ID())
+
+	revoked, next, err := g.RerollUser(user.ID)
+	switch {
+	case errors.Is(err, game.ErrNotAWinner):
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{
+			Content: fmt.Sprintf("<@%v> isn't a winner of the last Hunger Games in this channel.", user.ID),
+		})
+	case err != nil:
+		log.Errorf("could not reroll %v in game %v: %v", user.ID, g.ID(), err)
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: "The Gamemakers could not reroll that victor. Please try again."})
+	case next.Participant == nil:
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{
+			Content: fmt.Sprintf("Removed <@%v> as a winner, but no tributes remain to take %v.", user.ID, revoked.Prize),
+		})
+	default:
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{
+			Content: fmt.Sprintf("Replaced <@%v> with <@%v> (%v place).", user.ID, next.Participant.User.ID, lib.Ordinal(next.Place)),
+		})
+	}
+}
+
+// restoreLastGame rebuilds the channel's last finished game from the store, for
+// rerolls of games that finished before the bot restarted.
+func (m *Manager) restoreLastGame(session *discordgo.Session, ic *discordgo.InteractionCreate) *game.Game {
+	recs, _, err := m.store.ListGames(ic.GuildID, 0, 0)
+	if err != nil {
+		log.Errorf("could not load the history of guild %v to reroll: %v", ic.GuildID, err)
+		return nil
+	}
+
+	for _, rec := range recs {
+		if rec.ChannelID == ic.ChannelID && rec.State == storage.GameFinished {
+			log.Infof("restoring game %v from the store to reroll", rec.ID)
+			return game.ManagerInstance(session).RestoreGame(rec, m.store)
+		}
+	}
+
+	return nil
+}
+
+// RestoreClaims rebuilds every finished game that still has unclaimed prizes,
+// so their claim buttons keep working and their deadlines expire after the bot
+// restarts. It needs the session's guilds, so call it once the session is open.
+func (m *Manager) RestoreClaims(session *discordgo.Session) {
+	for _, guild := range session.State.Guilds {
+		recs, _, err := m.store.ListGames(guild.ID, 0, 0)
+		if err != nil {
+			log.Errorf("could not load the history of guild %v to restore prize claims: %v", guild.ID, err)
+			continue
+		}
+
+		for _, rec := range recs {
+			if rec.State == storage.GameFinished && hasPendingClaims(rec) {
+				log.Infof("restoring game %v from the store for its prize claims", rec.ID)
+				game.ManagerInstance(session).RestoreGame(rec, m.store)
+			}
+		}
+	}
+}
+
+func hasPendingClaims(rec *storage.GameRecord) bool {
+	for _, a := range rec.Awards {
+		if a.Status == string(game.ClaimPending) {
+			return true
+		}
+	}
+
+	return false
+}
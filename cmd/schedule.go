@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/deadloct/bitheroes-hg-bot/game"
+	"github.com/deadloct/bitheroes-hg-bot/permissions"
+	"github.com/deadloct/bitheroes-hg-bot/settings"
+	log "github.com/sirupsen/logrus"
+)
+
+func scheduleOptions(description string) []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionInteger,
+			Name:        CommandScheduleOptionMinutes,
+			Description: description,
+			Required:    true,
+			MinValue:    &CommandScheduleOptionMinutesMinValue,
+		},
+	}
+}
+
+// handleSchedule moves the start of the channel's waiting game for
+// /hg-begin-now, /hg-extend and /hg-shorten. Whoever may cancel the game may
+// reschedule it.
+func (m *Manager) handleSchedule(session *discordgo.Session, ic *discordgo.InteractionCreate) {
+	if !m.checkPermission(session, ic, m.guildSettings(ic.GuildID), permissions.ActionCancel) {
+		return
+	}
+
+	g := game.ManagerInstance(session).ActiveGame(ic.ChannelID)
+	if g == nil {
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: "There is no Hunger Games in this channel right now. Start one with `/hg-start`."})
+		return
+	}
+
+	var minutes int64
+	for _, option := range ic.ApplicationCommandData().Options {
+		if option.Name == CommandScheduleOptionMinutes {
+			minutes = option.IntValue()
+		}
+	}
+
+	name := ic.ApplicationCommandData().Name
+	log.Infof("%v is rescheduling game %v with %v %v", ic.Member.User.ID, g.ID(), name, minutes)
+
+	var closes time.Time
+	var err error
+	switch name {
+	case CommandBeginNow:
+		err = g.BeginNow()
+	case CommandExtend:
+		closes, err = g.Extend(time.Duration(minutes) * time.Minute)
+	case CommandShorten:
+		closes, err = g.Shorten(time.Duration(minutes) * time.Minute)
+	}
+
+	switch {
+	case errors.Is(err, game.ErrEntriesClosed):
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: "Entries for this Hunger Games have already closed."})
+	case errors.Is(err, game.ErrStartTooLate):
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: fmt.Sprintf("The Gamemakers can't keep entries open for more than %v minutes.", settings.MaximumStartDelay)})
+	case err != nil:
+		log.Errorf("could not reschedule game %v: %v", g.ID(), err)
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: "The Gamemakers could not change the schedule. Please try again."})
+	case name == CommandBeginNow || !closes.After(time.Now()):
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: "Entries are closed. Let the games begin!"})
+	default:
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: fmt.Sprintf("Entries now close <t:%v:R>.", closes.Unix())})
+	}
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/deadloct/bitheroes-hg-bot/settings"
+	"github.com/deadloct/bitheroes-hg-bot/stats"
+	log "github.com/sirupsen/logrus"
+)
+
+func leaderboardSortChoices() []*discordgo.ApplicationCommandOptionChoice {
+	var choices []*discordgo.ApplicationCommandOptionChoice
+	for _, key := range stats.SortKeys {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: string(key), Value: string(key)})
+	}
+
+	return choices
+}
+
+func (m *Manager) guildStats(ic *discordgo.InteractionCreate) (map[string]*stats.PlayerStats, error) {
+	recs, _, err := m.store.ListGames(ic.GuildID, 0, 0)
+	if err != nil {
+		log.Errorf("could not list games in guild %v: %v", ic.GuildID, err)
+		return nil, err
+	}
+
+	return stats.Aggregate(recs), nil
+}
+
+func (m *Manager) handleStats(session *discordgo.Session, ic *discordgo.InteractionCreate) {
+	user := ic.Member.User
+	for _, option := range ic.ApplicationCommandData().Options {
+		if option.Name == CommandStatsOptionUser {
+			if v := option.UserValue(session); v != nil {
+				user = v
+			}
+		}
+	}
+
+	all, err := m.guildStats(ic)
+	if err != nil {
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: "The Capitol archives are unavailable right now."})
+		return
+	}
+
+	s, ok := all[user.ID]
+	if !ok {
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{
+			Content: fmt.Sprintf("<@%v> has never entered a Hunger Games in this server.", user.ID),
+		})
+		return
+	}
+
+	lines := []string{
+		fmt.Sprintf("**Games entered:** %v", s.Games),
+		fmt.Sprintf("**Wins:** %v (%.0f%%)", s.Wins, s.WinRate()*100),
+		fmt.Sprintf("**Average placement:** %.1f", s.AveragePlacement()),
+		fmt.Sprintf("**Kills:** %v", s.Kills),
+	}
+
+	m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{{
+			Title:       fmt.Sprintf("Hunger Games record for %v", s.Name),
+			Description: strings.Join(lines, "\n"),
+		}},
+	})
+}
+
+func (m *Manager) handleLeaderboard(session *discordgo.Session, ic *discordgo.InteractionCreate) {
+	key := stats.SortWins
+	for _, option := range ic.ApplicationCommandData().Options {
+		if option.Name == CommandLeaderboardOptionSort {
+			key = stats.SortKey(option.StringValue())
+		}
+	}
+
+	all, err := m.guildStats(ic)
+	if err != nil {
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: "The Capitol archives are unavailable right now."})
+		return
+	}
+
+	board := stats.Leaderboard(all, key, settings.LeaderboardMinGames)
+	if len(board) == 0 {
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: "There aren't enough finished games in this server for a leaderboard yet."})
+		return
+	}
+
+	if len(board) > settings.LeaderboardSize {
+		board = board[:settings.LeaderboardSize]
+	}
+
+	var lines []string
+	for i, s := range board {
+		lines = append(lines, fmt.Sprintf(
+			"%v. **%v** — %v wins in %v games, %v kills, avg. place %.1f",
+			i+1, s.Name, s.Wins, s.Games, s.Kills, s.AveragePlacement(),
+		))
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("Hunger Games Leaderboard (by %v)", key),
+		Description: strings.Join(lines, "\n"),
+	}
+
+	if key == stats.SortPlacement || key == stats.SortWinRate {
+		embed.Footer = &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Only players with at least %v games are ranked by %v.", settings.LeaderboardMinGames, key),
+		}
+	}
+
+	m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Embeds: []*discordgo.MessageEmbed{embed}})
+}
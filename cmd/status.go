@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/deadloct/bitheroes-hg-bot/game"
+)
+
+func (m *Manager) handleStatus(session *discordgo.Session, ic *discordgo.InteractionCreate) {
+	g := game.ManagerInstance(session).ActiveGame(ic.ChannelID)
+	if g == nil {
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: "There is no Hunger Games in this channel right now. Start one with `/hg-start`."})
+		return
+	}
+
+	m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{
+		Embeds:          []*discordgo.MessageEmbed{statusEmbed(g.Status())},
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	})
+}
+
+func statusEmbed(s game.Status) *discordgo.MessageEmbed {
+	lines := []string{
+		fmt.Sprintf("**State:** %v", s.State),
+		fmt.Sprintf("**Sponsor:** %v", s.Sponsor),
+	}
+
+	if s.StartedBy != nil && s.StartedBy.User != nil {
+		lines = append(lines, fmt.Sprintf("**Started by:** %v", s.StartedBy.Mention()))
+	}
+
+	if s.State == game.NotStarted {
+		lines = append(lines,
+			fmt.Sprintf("**Starts:** <t:%v:R>", s.EntriesClose.Unix()),
+			fmt.Sprintf("**Tributes entered:** %v", s.Entrants),
+		)
+	} else {
+		lines = append(lines,
+			fmt.Sprintf("**Tributes entered:** %v", s.Entrants),
+			fmt.Sprintf("**Tributes remaining:** %v", s.Alive),
+		)
+
+		if s.Day > 0 {
+			lines = append(lines, fmt.Sprintf("**Day:** %v", s.Day))
+		}
+
+		if s.Paused {
+			lines = append(lines, "**Paused** until the Gamemakers resume it")
+		}
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       "Hunger Games status",
+		Description: strings.Join(lines, "\n"),
+	}
+}
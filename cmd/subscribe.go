@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/deadloct/bitheroes-hg-bot/permissions"
+	log "github.com/sirupsen/logrus"
+)
+
+// handleSubscription adds or removes the guild's notification role for the member.
+func (m *Manager) handleSubscription(session *discordgo.Session, ic *discordgo.InteractionCreate, subscribe bool) {
+	gs := m.guildSettings(ic.GuildID)
+	if gs.NotificationRole == "" {
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{
+			Content: "This server has no Hunger Games ping role yet. An admin can choose one with `/hg-config set notification-role`.",
+		})
+		return
+	}
+
+	role := fmt.Sprintf("<@&%v>", gs.NotificationRole)
+	has := slices.Contains(ic.Member.Roles, gs.NotificationRole)
+	switch {
+	case subscribe && has:
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{
+			Content:         fmt.Sprintf("You already have %v and will be pinged when a Hunger Games begins.", role),
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		})
+		return
+	case !subscribe && !has:
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{
+			Content:         fmt.Sprintf("You don't have %v, so you won't be pinged.", role),
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		})
+		return
+	}
+
+	if err := m.canManageRole(session, ic.GuildID, gs.NotificationRole); err != nil {
+		log.Warnf("cannot manage notification role %v in guild %v: %v", gs.NotificationRole, ic.GuildID, err)
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{
+			Content:         fmt.Sprintf("The Capitol can't hand out %v because %v. Please let an admin know.", role, err),
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		})
+		return
+	}
+
+	var err error
+	if subscribe {
+		err = session.GuildMemberRoleAdd(ic.GuildID, ic.Member.User.ID, gs.NotificationRole)
+	} else {
+		err = session.GuildMemberRoleRemove(ic.GuildID, ic.Member.User.ID, gs.NotificationRole)
+	}
+
+	if err != nil {
+		log.Errorf("could not change notification role %v for %v in guild %v: %v", gs.NotificationRole, ic.Member.User.ID, ic.GuildID, err)
+		m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{Content: "The Capitol couldn't change your roles. Please try again."})
+		return
+	}
+
+	content := fmt.Sprintf("You now have %v and will be pinged when a Hunger Games begins.", role)
+	if !subscribe {
+		content = fmt.Sprintf("Removed %v. You won't be pinged for new Hunger Games anymore.", role)
+	}
+
+	log.Infof("user %v subscribed=%v to notification role %v in guild %v", ic.Member.User.ID, subscribe, gs.NotificationRole, ic.GuildID)
+	m.respondEphemeral(session, ic, &discordgo.InteractionResponseData{
+		Content:         content,
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	})
+}
+
+func (m *Manager) canManageRole(session *discordgo.Session, guildID, roleID string) error {
+	roles, err := session.GuildRoles(guildID)
+	if err != nil {
+		return err
+	}
+
+	bot, err := session.GuildMember(guildID, session.State.User.ID)
+	if err != nil {
+		return err
+	}
+
+	return permissions.CanManageRole(guildID, roles, bot, roleID)
+}
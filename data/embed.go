@@ -1,12 +1,11 @@
 package data
 
-import _ "embed"
+import "embed"
 
-//go:embed help.en.template
-var HelpTemplate string
-
-//go:embed intro.en.template
-var IntroTemplate string
+// Templates holds the intro and help templates, named <kind>.<locale>.template.
+//
+//go:embed help.*.template intro.*.template
+var Templates embed.FS
 
 //go:embed jokes.en.json
 var JokesJSON []byte
@@ -0,0 +1,421 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/deadloct/bitheroes-hg-bot/lib"
+	"github.com/deadloct/bitheroes-hg-bot/settings"
+	log "github.com/sirupsen/logrus"
+)
+
+type ClaimStatus string
+
+const (
+	ClaimPending ClaimStatus = "pending"
+	ClaimClaimed ClaimStatus = "claimed"
+	ClaimExpired ClaimStatus = "expired"
+	ClaimRevoked ClaimStatus = "revoked" // removed by /hg-reroll
+
+	ClaimButtonPrefix = "hg-claim"
+)
+
+// disqualification is a tribute removed with RerollUser and the tribute who
+// took over their prize, if any.
+type disqualification struct {
+	Revoked     *Participant
+	Replacement *Participant
+}
+
+var (
+	ErrClaimNotFound = errors.New("this prize is no longer available")
+	ErrClaimNotYours = errors.New("this prize belongs to somebody else")
+	ErrClaimClosed   = errors.New("this prize can no longer be claimed")
+	ErrNotAWinner    = errors.New("that user isn't a winner of this game")
+)
+
+func ClaimButtonID(gameID string, slot int) string {
+	return fmt.Sprintf("%v:%v:%v", ClaimButtonPrefix, gameID, slot)
+}
+
+// ParseClaimButtonID is the inverse of ClaimButtonID.
+func ParseClaimButtonID(customID string) (gameID string, slot int, err error) {
+	parts := strings.Split(customID, ":")
+	if len(parts) != 3 || parts[0] != ClaimButtonPrefix {
+		return "", 0, fmt.Errorf("not a claim button: %v", customID)
+	}
+
+	slot, err = strconv.Atoi(parts[2])
+	return parts[1], slot, err
+}
+
+// HasPendingClaims is true while any winner can still claim their prize.
+func (g *Game) HasPendingClaims() bool {
+	g.Lock()
+	defer g.Unlock()
+
+	for _, a := range g.currentAwards() {
+		if a.Status == ClaimPending {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Claim marks a slot as claimed by userID.
+func (g *Game) Claim(userID string, slot int) (Award, error) {
+	g.Lock()
+	i := g.currentAward(slot)
+	if i < 0 {
+		g.Unlock()
+		return Award{}, ErrClaimNotFound
+	}
+
+	a := &g.awards[i]
+	switch {
+	case a.Participant.User.ID != userID:
+		g.Unlock()
+		return Award{}, ErrClaimNotYours
+	case a.Status != ClaimPending || time.Now().After(a.Deadline):
+		g.Unlock()
+		return Award{}, ErrClaimClosed
+	}
+
+	a.Status = ClaimClaimed
+	if t, ok := g.claimTimers[slot]; ok {
+		t.Stop()
+		delete(g.claimTimers, slot)
+	}
+
+	award := *a
+	g.Unlock()
+
+	g.logMessage(log.InfoLevel, "%v claimed %v", award.Participant.DisplayFullName(), award.Prize)
+	g.updateClaimStatus()
+	g.saveRecord()
+
+	return award, nil
+}
+
+// startClaims DMs every winner a claim button and the sponsor a status message
+// that is edited as claims come in.
+func (g *Game) startClaims() {
+	g.Lock()
+	var awards []Award
+	for i := range g.awards {
+		g.armClaim(&g.awards[i])
+		awards = append(awards, g.awards[i])
+	}
+	g.Unlock()
+
+	for _, a := range awards {
+		g.sendClaimDM(a)
+	}
+
+	if g.StartedBy.User != nil {
+		msg, err := g.Sender.SendDMComplex(g.StartedBy.User, &discordgo.MessageSend{Content: g.claimStatusContent()})
+		if err != nil {
+			g.logMessage(log.ErrorLevel, "unable to send claim status to %v: %v", g.StartedBy.DisplayFullName(), err)
+		}
+
+		g.Lock()
+		g.claimStatus = msg
+		g.Unlock()
+	}
+
+	g.saveRecord()
+}
+
+// armClaim must be called with the lock held.
+func (g *Game) armClaim(a *Award) {
+	a.Status = ClaimPending
+	a.Deadline = time.Now().Add(g.ClaimDeadline)
+
+	slot := a.Slot
+	g.claimTimers[slot] = time.AfterFunc(g.ClaimDeadline, func() { g.expireClaim(slot) })
+}
+
+// resumeClaims rearms the timers of a restored game's pending claims. Claims
+// whose deadline passed while the bot was down expire right away.
+func (g *Game) resumeClaims() {
+	g.Lock()
+	var expired []int
+	for _, a := range g.currentAwards() {
+		if a.Status != ClaimPending {
+			continue
+		}
+
+		slot := a.Slot
+		remaining := time.Until(a.Deadline)
+		if remaining <= 0 {
+			expired = append(expired, slot)
+			continue
+		}
+
+		g.claimTimers[slot] = time.AfterFunc(remaining, func() { g.expireClaim(slot) })
+	}
+	g.Unlock()
+
+	for _, slot := range expired {
+		g.expireClaim(slot)
+	}
+}
+
+func (g *Game) sendClaimDM(a Award) {
+	msg := &discordgo.MessageSend{
+		Content: fmt.Sprintf(
+			"Congratulations, you placed %v in the Hunger Games event hosted by %s and won %v! Claim it <t:%v:R> or it will pass to the next tribute in the standings.",
+			lib.Ordinal(a.Place), g.StartedBy.DisplayFullName(), g.prizeLabel(a), a.Deadline.Unix(),
+		),
+		Components: []discordgo.MessageComponent{
+			discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "Claim prize",
+					Style:    discordgo.SuccessButton,
+					CustomID: ClaimButtonID(g.ID(), a.Slot),
+				},
+			}},
+		},
+	}
+
+	if _, err := g.Sender.SendDMComplex(a.Participant.User, msg); err != nil {
+		g.logMessage(log.ErrorLevel, "unable to send claim DM to %v: %v", a.Participant.DisplayFullName(), err)
+	}
+}
+
+func (g *Game) expireClaim(slot int) {
+	g.Lock()
+	i := g.currentAward(slot)
+	if i < 0 || g.awards[i].Status != ClaimPending {
+		g.Unlock()
+		return
+	}
+
+	g.awards[i].Status = ClaimExpired
+	delete(g.claimTimers, slot)
+	expired := g.awards[i]
+	g.Unlock()
+
+	g.logMessage(log.InfoLevel, "%v did not claim %v in time", expired.Participant.DisplayFullName(), expired.Prize)
+
+	next, ok := g.reroll(slot)
+	if !ok {
+		g.Sender.SendQuoted(fmt.Sprintf(
+			"%v did not claim %v in time, and no tributes remain to inherit it.",
+			expired.Participant.Mention(), g.prizeLabel(expired),
+		))
+	} else {
+		g.Sender.SendQuoted(fmt.Sprintf(
+			"%v did not claim %v in time. It now passes to %v (%v place)!",
+			expired.Participant.Mention(), g.prizeLabel(expired), next.Participant.Mention(), lib.Ordinal(next.Place),
+		))
+	}
+
+	g.updateClaimStatus()
+	g.saveRecord()
+}
+
+// reroll gives slot to the best ranked tribute that hasn't held any prize yet.
+func (g *Game) reroll(slot int) (Award, bool) {
+	g.Lock()
+	i := g.currentAward(slot)
+	if i < 0 {
+		g.Unlock()
+		return Award{}, false
+	}
+
+	held := make(map[string]struct{})
+	for _, a := range g.awards {
+		held[a.Participant.User.ID] = struct{}{}
+	}
+
+	var next *Award
+	for _, r := range g.ranked {
+		if _, ok := held[r.Participant.User.ID]; !ok {
+			next = &Award{Slot: slot, Prize: g.awards[i].Prize, Place: r.Place, Participant: r.Participant}
+			break
+		}
+	}
+
+	if next == nil {
+		g.Unlock()
+		return Award{}, false
+	}
+
+	if g.ClaimDeadline > 0 {
+		g.armClaim(next)
+	}
+
+	g.awards = append(g.awards, *next)
+	award := *next
+	g.Unlock()
+
+	g.logMessage(log.InfoLevel, "rerolled %v to %v", award.Prize, award.Participant.DisplayFullName())
+
+	if g.ClaimDeadline > 0 {
+		g.sendClaimDM(award)
+	}
+
+	return award, true
+}
+
+// RerollUser takes userID's prize away and gives it to the next best ranked
+// tribute, returning the revoked and the new award.
+func (g *Game) RerollUser(userID string) (Award, Award, error) {
+	if !g.IsFinished() {
+		return Award{}, Award{}, ErrNotAWinner
+	}
+
+	g.Lock()
+
+	// Games without prizes or claims only know their victors, so give each a
+	// slot now. This happens under the same lock as the check so concurrent
+	// rerolls can't both assign them.
+	if len(g.awards) == 0 {
+		if err := g.assignAwards(); err != nil {
+			g.Unlock()
+			return Award{}, Award{}, err
+		}
+	}
+
+	i := -1
+	for _, a := range g.currentAwards() {
+		if a.Participant.User.ID == userID && a.Status != ClaimExpired && a.Status != ClaimRevoked {
+			i = g.currentAward(a.Slot)
+			break
+		}
+	}
+
+	if i < 0 {
+		g.Unlock()
+		return Award{}, Award{}, ErrNotAWinner
+	}
+
+	g.awards[i].Status = ClaimRevoked
+	if t, ok := g.claimTimers[g.awards[i].Slot]; ok {
+		t.Stop()
+		delete(g.claimTimers, g.awards[i].Slot)
+	}
+	revoked := g.awards[i]
+	g.Unlock()
+
+	g.logMessage(log.InfoLevel, "revoked %v from %v", revoked.Prize, revoked.Participant.DisplayFullName())
+
+	next, ok := g.reroll(revoked.Slot)
+
+	g.Lock()
+	d := disqualification{Revoked: revoked.Participant}
+	if ok {
+		d.Replacement = next.Participant
+	}
+	g.disqualified = append(g.disqualified, d)
+	g.Unlock()
+
+	if !ok {
+		g.Sender.SendQuoted(fmt.Sprintf(
+			"The Gamemakers have disqualified %v, but no tributes remain to inherit %v.",
+			revoked.Participant.Mention(), g.prizeLabel(revoked),
+		))
+	} else {
+		g.Sender.SendQuoted(fmt.Sprintf(
+			"The Gamemakers have disqualified %v. %v now passes to %v (%v place)!",
+			revoked.Participant.Mention(), g.prizeLabel(revoked), next.Participant.Mention(), lib.Ordinal(next.Place),
+		))
+
+		if g.ClaimDeadline == 0 {
+			msg := fmt.Sprintf(
+				"Congratulations, a prize from the Hunger Games event hosted by %s has passed to you: %v! Please get in touch with them if you haven't already.",
+				g.StartedBy.DisplayFullName(), g.prizeLabel(next),
+			)
+
+			if err := g.Sender.SendDM(next.Participant.User, msg); err != nil {
+				g.logMessage(log.ErrorLevel, "unable to create DM to participant %s for reroll notification: %v", next.Participant.DisplayFullName(), err)
+			}
+		}
+	}
+
+	msg := fmt.Sprintf(
+		"The host of the Hunger Games event you won, %s, has removed you as a winner and %v has passed to another tribute. Please contact them if you think this is a mistake.",
+		g.StartedBy.DisplayFullName(), g.prizeLabel(revoked),
+	)
+
+	if err := g.Sender.SendDM(revoked.Participant.User, msg); err != nil {
+		g.logMessage(log.ErrorLevel, "unable to create DM to participant %s for reroll notification: %v", revoked.Participant.DisplayFullName(), err)
+	}
+
+	g.updateClaimStatus()
+	g.saveRecord()
+
+	if !ok {
+		return revoked, Award{}, nil
+	}
+
+	return revoked, next, nil
+}
+
+func (g *Game) updateClaimStatus() {
+	g.Lock()
+	msg := g.claimStatus
+	g.Unlock()
+
+	if msg == nil {
+		return
+	}
+
+	content := g.claimStatusContent()
+	if _, err := g.Sender.EditComplex(&discordgo.MessageEdit{ID: msg.ID, Channel: msg.ChannelID, Content: &content}); err != nil {
+		g.logMessage(log.ErrorLevel, "unable to update claim status for %v: %v", g.StartedBy.DisplayFullName(), err)
+	}
+}
+
+func (g *Game) claimStatusContent() string {
+	lines := []string{"Prize claims for your Hunger Games event:"}
+	for _, a := range g.Awards() {
+		var status string
+		switch a.Status {
+		case ClaimPending:
+			status = fmt.Sprintf("waiting, expires <t:%v:R>", a.Deadline.Unix())
+		case ClaimClaimed:
+			status = "claimed"
+		case ClaimExpired:
+			status = "not claimed in time"
+		case ClaimRevoked:
+			status = "removed by the host"
+		}
+
+		lines = append(lines, fmt.Sprintf("* %v to %v: %v", g.prizeLabel(a), a.Participant.DisplayFullName(), status))
+	}
+
+	return lib.Truncate(strings.Join(lines, "\n"), settings.DiscordMaxMsgLen)
+}
+
+// currentAward returns the index of the latest award for slot, or -1. It must
+// be called with the lock held.
+func (g *Game) currentAward(slot int) int {
+	for i := len(g.awards) - 1; i >= 0; i-- {
+		if g.awards[i].Slot == slot {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// currentAwards must be called with the lock held.
+func (g *Game) currentAwards() []Award {
+	seen := make(map[int]struct{})
+	var awards []Award
+	for i := len(g.awards) - 1; i >= 0; i-- {
+		if _, ok := seen[g.awards[i].Slot]; !ok {
+			seen[g.awards[i].Slot] = struct{}{}
+			awards = append(awards, g.awards[i])
+		}
+	}
+
+	return awards
+}
@@ -14,7 +14,10 @@ type Sender interface {
 	Send(str string) (*discordgo.Message, error)
 	SendQuoted(str string) (*discordgo.Message, error)
 	SendEmbed(str string) (*discordgo.Message, error)
+	SendComplex(msg *discordgo.MessageSend) (*discordgo.Message, error)
 	SendDM(user *discordgo.User, msg string) error
+	SendDMComplex(user *discordgo.User, msg *discordgo.MessageSend) (*discordgo.Message, error)
+	EditComplex(edit *discordgo.MessageEdit) (*discordgo.Message, error)
 }
 
 type SendingFunc func(str string) (*discordgo.Message, error)
@@ -43,6 +46,17 @@ func (s *DiscordSender) SendEmbed(str string) (*discordgo.Message, error) {
 	return s.send(str, s.flushEmbed)
 }
 
+// SendComplex sends msg as-is without splitting, so the content must already fit in a single message.
+func (s *DiscordSender) SendComplex(msg *discordgo.MessageSend) (*discordgo.Message, error) {
+	log.Tracef("sending complex message of length %v", len(msg.Content))
+	m, err := s.session.ChannelMessageSendComplex(s.channelID, msg)
+	if err != nil {
+		log.Errorf("error sending complex message of length %v: %v", len(msg.Content), err)
+	}
+
+	return m, err
+}
+
 func (s *DiscordSender) SendDM(user *discordgo.User, msg string) error {
 	dmChannel, err := s.session.UserChannelCreate(user.ID)
 	if err != nil {
@@ -56,6 +70,20 @@ func (s *DiscordSender) SendDM(user *discordgo.User, msg string) error {
 	return nil
 }
 
+func (s *DiscordSender) SendDMComplex(user *discordgo.User, msg *discordgo.MessageSend) (*discordgo.Message, error) {
+	dmChannel, err := s.session.UserChannelCreate(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.session.ChannelMessageSendComplex(dmChannel.ID, msg)
+}
+
+// EditComplex edits any message the bot has sent, including DMs.
+func (s *DiscordSender) EditComplex(edit *discordgo.MessageEdit) (*discordgo.Message, error) {
+	return s.session.ChannelMessageEditComplex(edit)
+}
+
 func (s *DiscordSender) send(str string, sender SendingFunc) (*discordgo.Message, error) {
 	lines := strings.Split(str, "\n")
 
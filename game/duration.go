@@ -0,0 +1,98 @@
+package game
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/deadloct/bitheroes-hg-bot/settings"
+	log "github.com/sirupsen/logrus"
+)
+
+// planDuration picks the day delay that makes the game last about
+// TargetDuration once entries close, and announces the estimate. Games without
+// a pacing of their own get the finish-in pacing so the day count is known.
+// Days last between settings.MinimumDayDelay and settings.MaximumDayDelay, so a
+// target that doesn't fit the days finishes sooner or later than asked.
+func (g *Game) planDuration() {
+	g.Lock()
+	alive := len(g.participants) * max(1, g.Clone)
+	if g.TargetDuration <= 0 || alive <= g.VictorCount {
+		g.Unlock()
+		return
+	}
+
+	if g.Pacing == nil {
+		// About as many days as a classic game of this size takes, or more when
+		// the days would be too long, unless that makes them too short to read.
+		days := int(math.Ceil(math.Log2(float64(alive) / float64(g.VictorCount))))
+		days = max(days, int(math.Ceil(float64(g.TargetDuration)/float64(settings.MaximumDayDelay))))
+		days = min(days, int(g.TargetDuration/settings.MinimumDayDelay), settings.MaximumFinishDays)
+		g.Pacing = FinishInPacing{Days: max(days, 1)}
+	}
+
+	days := expectedDays(g.Pacing, 0, alive, g.VictorCount)
+	g.DayDelay = clampDayDelay(g.TargetDuration / time.Duration(days))
+	g.deadline = time.Now().Add(g.DayDelay * time.Duration(days))
+	delay, deadline := g.DayDelay, g.deadline
+	g.Unlock()
+
+	g.logMessage(log.InfoLevel, "planned %v days of %v for a target duration of %v", days, delay, g.TargetDuration)
+	victors := "victor"
+	if g.VictorCount > 1 {
+		victors = "victors"
+	}
+
+	g.Sender.SendQuoted(fmt.Sprintf(
+		"Entries are closed. The Gamemakers expect to crown the %v in about %v, <t:%v:R>.",
+		victors, pluralize(days, "day"), deadline.Unix(),
+	))
+}
+
+// dayDelay is the wait before day. Games with a target duration spread the time
+// left over the days the pacing still expects, so slow or quick days even out.
+// A game past its deadline keeps the shortest days rather than none at all.
+func (g *Game) dayDelay(day int) time.Duration {
+	g.Lock()
+	if g.TargetDuration <= 0 || g.deadline.IsZero() {
+		delay := g.DayDelay
+		g.Unlock()
+		return delay
+	}
+
+	days := expectedDays(g.pacing(), day, len(g.participants), g.VictorCount)
+	delay := clampDayDelay(time.Until(g.deadline) / time.Duration(days))
+	previous := g.DayDelay
+	g.DayDelay = delay
+	g.Unlock()
+
+	if drift := delay - previous; drift > time.Second || drift < -time.Second {
+		g.logMessage(log.InfoLevel, "adjusting the day delay from %v to %v with %v days expected", previous, delay, days)
+	}
+
+	return delay
+}
+
+func clampDayDelay(d time.Duration) time.Duration {
+	return min(max(d, settings.MinimumDayDelay), settings.MaximumDayDelay)
+}
+
+// expectedDays estimates how many days are left from day with alive tributes,
+// assuming each day kills the middle of the pacing's range.
+func expectedDays(p PacingStrategy, day, alive, victors int) int {
+	var days, quiet int
+	for ; alive > victors; day++ {
+		days++
+		low, high := p.KillRange(day, alive, victors)
+		kills := min((low+high)/2, alive-victors)
+		if kills == 0 {
+			if quiet++; quiet >= settings.MaxQuietDays {
+				kills, quiet = 1, 0
+			}
+		}
+
+		alive -= kills
+	}
+
+	return max(days, 1)
+}
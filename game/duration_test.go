@@ -0,0 +1,108 @@
+package game
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deadloct/bitheroes-hg-bot/settings"
+)
+
+func TestExpectedDays(t *testing.T) {
+	tests := []struct {
+		Pacing         PacingStrategy
+		Alive, Victors int
+		Days           int
+	}{
+		{Pacing: FinishInPacing{Days: 4}, Alive: 50, Victors: 1, Days: 4},
+		{Pacing: FinishInPacing{Days: 1}, Alive: 50, Victors: 1, Days: 1},
+		{Pacing: ClassicPacing{}, Alive: 2, Victors: 1, Days: 1},
+		{Pacing: ClassicPacing{}, Alive: 1, Victors: 1, Days: 1},
+	}
+
+	for _, test := range tests {
+		if days := expectedDays(test.Pacing, 0, test.Alive, test.Victors); days != test.Days {
+			t.Errorf("%v with %v alive: expected %v days but got %v", test.Pacing.Name(), test.Alive, test.Days, days)
+		}
+	}
+}
+
+func TestGame_PlanDuration(t *testing.T) {
+	_, members := testSetupGameRun(t, 32, 1)
+	sender := &BufferSender{}
+	g := testGame(GameConfig{
+		Sender:         sender,
+		TargetDuration: 10 * time.Minute,
+		VictorCount:    1,
+	})
+
+	for _, m := range members {
+		g.participants = append(g.participants, NewParticipant(m))
+	}
+
+	g.planDuration()
+
+	if g.Pacing != (FinishInPacing{Days: 5}) {
+		t.Fatalf("expected 32 tributes to get 5 days but got %#v", g.Pacing)
+	}
+
+	if g.DayDelay != 2*time.Minute {
+		t.Errorf("expected 2 minute days but got %v", g.DayDelay)
+	}
+
+	if len(sender.buffer) != 1 || !strings.Contains(sender.buffer[0], "about 5 days") {
+		t.Errorf("expected the estimate to be announced but got %v", sender.buffer)
+	}
+
+	// Long targets get more days rather than days that drag on.
+	g.Pacing = nil
+	g.TargetDuration = 4 * time.Hour
+	g.planDuration()
+	if g.Pacing != (FinishInPacing{Days: settings.MaximumFinishDays}) || g.DayDelay != settings.MaximumDayDelay {
+		t.Errorf("expected the longest days of the longest finish-in pacing but got %#v with %v days", g.Pacing, g.DayDelay)
+	}
+
+	// A game running late makes up for it with shorter days.
+	g.deadline = time.Now().Add(time.Minute)
+	if delay := g.dayDelay(3); delay > 30*time.Second {
+		t.Errorf("expected the last 2 days to share the minute left but got %v", delay)
+	}
+
+	// A game past its deadline still gives each day time to be read.
+	g.deadline = time.Now().Add(-time.Minute)
+	if delay := g.dayDelay(3); delay != settings.MinimumDayDelay {
+		t.Errorf("expected the shortest days once past the deadline but got %v", delay)
+	}
+}
+
+func TestGame_Run_CancelDuringDayDelay(t *testing.T) {
+	jp, members := testSetupGameRun(t, 10, 1)
+	g := testGame(GameConfig{
+		DayDelay:        time.Hour,
+		PhraseGenerator: jp,
+		VictorCount:     1,
+	})
+
+	for _, m := range members {
+		g.participants = append(g.participants, NewParticipant(m))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		g.run(ctx)
+		close(done)
+	}()
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected cancelling to end the game without waiting out the day")
+	}
+
+	if s := g.Status(); s.State != Cancelled {
+		t.Errorf("expected the game to be cancelled but got %v", s.State)
+	}
+}
@@ -0,0 +1,127 @@
+package game
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	log "github.com/sirupsen/logrus"
+)
+
+// Eligibility holds the entry rules of a game. The zero value lets everybody in.
+type Eligibility struct {
+	RequiredRoles []string       // entrants need at least one of these
+	ExcludedRoles []string       // entrants may have none of these
+	MinAccountAge time.Duration  // since the Discord account was created
+	MinMembership time.Duration  // since the entrant joined the guild
+	TierRoles     map[string]int // maps role ID to Bit Heroes tier, enforces GameConfig.MinimumTier when set
+}
+
+// IneligibleError explains to the entrant why they can't enter.
+type IneligibleError struct {
+	Reason string
+}
+
+func (e *IneligibleError) Error() string {
+	return e.Reason
+}
+
+// Check returns an *IneligibleError if m may not enter a game with minimumTier.
+func (e Eligibility) Check(m *discordgo.Member, minimumTier int, now time.Time) error {
+	if len(e.RequiredRoles) > 0 && !slices.ContainsFunc(m.Roles, func(r string) bool { return slices.Contains(e.RequiredRoles, r) }) {
+		return &IneligibleError{fmt.Sprintf("you need one of these roles to enter: %v", roleMentions(e.RequiredRoles))}
+	}
+
+	for _, r := range m.Roles {
+		if slices.Contains(e.ExcludedRoles, r) {
+			return &IneligibleError{fmt.Sprintf("tributes with the <@&%v> role can't enter", r)}
+		}
+	}
+
+	if e.MinAccountAge > 0 {
+		created, err := discordgo.SnowflakeTimestamp(m.User.ID)
+		if err == nil && now.Sub(created) < e.MinAccountAge {
+			return &IneligibleError{fmt.Sprintf("your Discord account must be at least %v old to enter", formatDays(e.MinAccountAge))}
+		}
+	}
+
+	if e.MinMembership > 0 && (m.JoinedAt.IsZero() || now.Sub(m.JoinedAt) < e.MinMembership) {
+		return &IneligibleError{fmt.Sprintf("you must have been a member of this server for at least %v to enter", formatDays(e.MinMembership))}
+	}
+
+	if minimumTier > 1 && len(e.TierRoles) > 0 {
+		var tier int
+		for _, r := range m.Roles {
+			tier = max(tier, e.TierRoles[r])
+		}
+
+		if tier < minimumTier {
+			return &IneligibleError{fmt.Sprintf("you need a role for Tier %v or higher to enter", minimumTier)}
+		}
+	}
+
+	return nil
+}
+
+// Rules describes the entry rules for the intro, except for the minimum tier
+// which the intro already covers.
+func (e Eligibility) Rules() []string {
+	var rules []string
+	if len(e.RequiredRoles) > 0 {
+		rules = append(rules, fmt.Sprintf("You need one of these roles to enter: %v.", roleMentions(e.RequiredRoles)))
+	}
+
+	if len(e.ExcludedRoles) > 0 {
+		rules = append(rules, fmt.Sprintf("Tributes with these roles can't enter: %v.", roleMentions(e.ExcludedRoles)))
+	}
+
+	if e.MinAccountAge > 0 {
+		rules = append(rules, fmt.Sprintf("Your Discord account must be at least %v old.", formatDays(e.MinAccountAge)))
+	}
+
+	if e.MinMembership > 0 {
+		rules = append(rules, fmt.Sprintf("You must have been a member of this server for at least %v.", formatDays(e.MinMembership)))
+	}
+
+	return rules
+}
+
+// notifyIneligible DMs a tribute who reacted to the intro but can't enter.
+// Buttons answer ephemerally instead. Each tribute is only told once per game.
+func (g *Game) notifyIneligible(p *Participant, err *IneligibleError) {
+	g.Lock()
+	_, told := g.rejected[p.User.ID]
+	g.rejected[p.User.ID] = struct{}{}
+	g.Unlock()
+
+	if told {
+		return
+	}
+
+	msg := fmt.Sprintf("Sorry, you can't enter the Hunger Games event sponsored by %v in <#%v>: %v.", g.Sponsor, g.Channel.ID, err)
+	if dmErr := g.Sender.SendDM(p.User, msg); dmErr != nil {
+		g.logMessage(log.ErrorLevel, "unable to tell %v why they can't enter: %v", p.DisplayFullName(), dmErr)
+	}
+}
+
+func roleMentions(roles []string) string {
+	var mentions string
+	for i, r := range roles {
+		if i > 0 {
+			mentions += ", "
+		}
+		mentions += fmt.Sprintf("<@&%v>", r)
+	}
+
+	return mentions
+}
+
+func formatDays(d time.Duration) string {
+	days := int(d / (24 * time.Hour))
+	if days == 1 {
+		return "1 day"
+	}
+
+	return fmt.Sprintf("%v days", days)
+}
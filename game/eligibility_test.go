@@ -0,0 +1,64 @@
+package game
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestEligibility_Check(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	// Snowflakes carry their creation time in milliseconds since the Discord epoch.
+	snowflake := func(created time.Time) string {
+		return strconv.FormatInt((created.UnixMilli()-1420070400000)<<22, 10)
+	}
+
+	member := func(created, joined time.Time, roles ...string) *discordgo.Member {
+		return &discordgo.Member{User: &discordgo.User{ID: snowflake(created)}, JoinedAt: joined, Roles: roles}
+	}
+
+	old := now.AddDate(-1, 0, 0)
+	rules := Eligibility{
+		RequiredRoles: []string{"players"},
+		ExcludedRoles: []string{"banned"},
+		MinAccountAge: 30 * 24 * time.Hour,
+		MinMembership: 7 * 24 * time.Hour,
+		TierRoles:     map[string]int{"t5": 5, "t10": 10},
+	}
+
+	tests := map[string]struct {
+		member   *discordgo.Member
+		eligible bool
+	}{
+		"eligible":          {member(old, old, "players", "t10"), true},
+		"missing role":      {member(old, old, "t10"), false},
+		"excluded role":     {member(old, old, "players", "banned", "t10"), false},
+		"new account":       {member(now.AddDate(0, 0, -1), old, "players", "t10"), false},
+		"new member":        {member(old, now.AddDate(0, 0, -1), "players", "t10"), false},
+		"tier too low":      {member(old, old, "players", "t5"), false},
+		"no tier role":      {member(old, old, "players"), false},
+		"unknown join date": {member(old, time.Time{}, "players", "t10"), false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := rules.Check(tc.member, 8, now)
+			if tc.eligible != (err == nil) {
+				t.Fatalf("expected eligible to be %v but got %v", tc.eligible, err)
+			}
+
+			var ineligible *IneligibleError
+			if err != nil && !errors.As(err, &ineligible) {
+				t.Errorf("expected an IneligibleError but got %T", err)
+			}
+		})
+	}
+
+	if err := (Eligibility{}).Check(member(now, time.Time{}), 8, now); err != nil {
+		t.Errorf("expected no rules to let everybody in but got %v", err)
+	}
+}
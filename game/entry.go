@@ -0,0 +1,137 @@
+package game
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	log "github.com/sirupsen/logrus"
+)
+
+type EntryMode string
+
+const (
+	EntryReactions EntryMode = "reactions"
+	EntryButtons   EntryMode = "buttons"
+
+	EnterButtonID    = "hg-enter"
+	WithdrawButtonID = "hg-withdraw"
+)
+
+var (
+	ErrEntriesClosed  = errors.New("entries for this Hunger Games are closed")
+	ErrAlreadyEntered = errors.New("you have already entered")
+	ErrNotEntered     = errors.New("you haven't entered")
+	ErrBotEntrant     = errors.New("bots can't enter")
+)
+
+// Enter registers a tribute who pressed the intro's Enter button and returns
+// the number of tributes so far.
+func (g *Game) Enter(messageID string, participant *Participant) (int, error) {
+	if g.introMessage == nil || messageID != g.introMessage.ID {
+		return 0, ErrEntriesClosed
+	}
+
+	return g.register(participant)
+}
+
+// Withdraw removes a tribute who pressed the intro's Withdraw button and
+// returns the number of tributes left.
+func (g *Game) Withdraw(messageID, userID string) (int, error) {
+	if g.introMessage == nil || messageID != g.introMessage.ID {
+		return 0, ErrEntriesClosed
+	}
+
+	return g.withdraw(userID)
+}
+
+// register applies the entry rules shared by reactions and buttons.
+func (g *Game) register(participant *Participant) (int, error) {
+	if participant.User.Bot {
+		return 0, ErrBotEntrant
+	}
+
+	if err := g.checkEntry(participant); err != nil {
+		return 0, err
+	}
+
+	// Hold the lock from the state check on so entries can't slip in after run
+	// has taken its snapshot of the participants.
+	g.Lock()
+	defer g.Unlock()
+
+	if g.state != NotStarted {
+		return len(g.participants), ErrEntriesClosed
+	}
+
+	if _, ok := g.participantMap[participant.User.ID]; ok {
+		return len(g.participants), ErrAlreadyEntered
+	}
+
+	g.participantMap[participant.User.ID] = participant
+	g.participants = append(g.participants, participant)
+	g.logMessage(log.InfoLevel, "Registered user %v", participant.DisplayFullName())
+
+	return len(g.participants), nil
+}
+
+// checkEntry applies the game's eligibility rules and the winner cooldown. It
+// doesn't lock, so it's safe to call while holding g's lock.
+func (g *Game) checkEntry(participant *Participant) error {
+	if err := g.Eligibility.Check(participant.Member, g.MinimumTier, time.Now()); err != nil {
+		return err
+	}
+
+	if reason, ok := g.Winners.Cooldown(participant.User.ID); ok {
+		return &IneligibleError{reason}
+	}
+
+	return nil
+}
+
+func (g *Game) withdraw(userID string) (int, error) {
+	g.Lock()
+	defer g.Unlock()
+
+	if g.state != NotStarted {
+		return len(g.participants), ErrEntriesClosed
+	}
+
+	participant, ok := g.participantMap[userID]
+	if !ok {
+		return len(g.participants), ErrNotEntered
+	}
+
+	delete(g.participantMap, userID)
+	g.participants = slices.DeleteFunc(g.participants, func(p *Participant) bool { return p.User.ID == userID })
+	g.logMessage(log.InfoLevel, "Withdrew user %v", participant.DisplayFullName())
+
+	return len(g.participants), nil
+}
+
+func entryButtons() []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+			discordgo.Button{Label: "Enter", Style: discordgo.SuccessButton, CustomID: EnterButtonID},
+			discordgo.Button{Label: "Withdraw", Style: discordgo.SecondaryButton, CustomID: WithdrawButtonID},
+		}},
+	}
+}
+
+// closeEntries removes the entry buttons from the intro once the game starts.
+func (g *Game) closeEntries() {
+	if g.EntryMode != EntryButtons || g.introMessage == nil {
+		return
+	}
+
+	components := []discordgo.MessageComponent{}
+	_, err := g.Sender.EditComplex(&discordgo.MessageEdit{
+		ID:         g.introMessage.ID,
+		Channel:    g.introMessage.ChannelID,
+		Components: &components,
+	})
+	if err != nil {
+		g.logMessage(log.ErrorLevel, "unable to remove entry buttons: %v", err)
+	}
+}
@@ -0,0 +1,137 @@
+package game
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/deadloct/bitheroes-hg-bot/lib"
+	log "github.com/sirupsen/logrus"
+)
+
+const EntrantsFileName = "entrants.txt"
+
+// EntrantsFile lists one user ID per line in registration order. Its SHA-256 is
+// the entrant list hash revealed at the end of a provably fair game.
+func EntrantsFile(entrants []*Participant) []byte {
+	var buf bytes.Buffer
+	for _, p := range entrants {
+		buf.WriteString(p.User.ID)
+		buf.WriteString("\n")
+	}
+
+	return buf.Bytes()
+}
+
+// ParseEntrantsFile is the inverse of EntrantsFile.
+func ParseEntrantsFile(data []byte) []string {
+	var ids []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if id := strings.TrimSpace(line); id != "" {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+func (g *Game) sendReveal() {
+	file := EntrantsFile(g.entrants)
+	verify := fmt.Sprintf(
+		"hg-verify -seed %v -victors %v -clone %v -prizes %v -entrants %v",
+		g.Seed, g.VictorCount, g.Clone, len(g.Prizes), EntrantsFileName,
+	)
+	if name := g.pacing().Name(); name != PacingClassic {
+		verify += " -pacing " + name
+	}
+
+	if len(g.SurvivalWeights) > 0 {
+		verify += " -weights " + FormatWeights(g.SurvivalWeights)
+	}
+
+	lines := []string{
+		"> The Gamemakers reveal the seed for this year's Hunger Games:",
+		fmt.Sprintf("> Seed: `%v`", g.Seed),
+		fmt.Sprintf("> Seed commitment: `%v`", lib.SeedCommitment(g.Seed)),
+		fmt.Sprintf("> Entrant list hash: `%v`", lib.SHA256Hex(file)),
+		fmt.Sprintf("> Verify with: `%v`", verify),
+	}
+
+	_, err := g.Sender.SendComplex(&discordgo.MessageSend{
+		Content: strings.Join(lines, "\n"),
+		Files: []*discordgo.File{{
+			Name:        EntrantsFileName,
+			ContentType: "text/plain",
+			Reader:      bytes.NewReader(file),
+		}},
+	})
+	if err != nil {
+		g.logMessage(log.ErrorLevel, "unable to send provably fair reveal: %v", err)
+	}
+}
+
+type ReplayConfig struct {
+	Clone       int
+	EntrantIDs  []string
+	Pacing      PacingStrategy
+	PhraseData  []byte
+	PrizeCount  int
+	Seed        uint64
+	VictorCount int
+	Weights     map[string]float64
+}
+
+// Replay reruns a game offline and returns it once finished. Given the same
+// seed, entrants and phrase data it reproduces the original result exactly.
+func Replay(cfg ReplayConfig) *Game {
+	rng := lib.NewSeededRandomizer(cfg.Seed)
+
+	var prizes []string
+	for i := 0; i < cfg.PrizeCount; i++ {
+		prizes = append(prizes, fmt.Sprintf("%v prize", lib.Ordinal(i+1)))
+	}
+
+	g := NewGame(GameConfig{
+		Channel:         &discordgo.Channel{},
+		Guild:           &discordgo.Guild{},
+		DayDelay:        time.Nanosecond,
+		Clone:           cfg.Clone,
+		Pacing:          cfg.Pacing,
+		PhraseGenerator: lib.NewJSONPhrases(cfg.PhraseData, rng),
+		Prizes:          prizes,
+		Randomizer:      rng,
+		Seed:            cfg.Seed,
+		Sender:          discardSender{},
+		StartedBy:       NewParticipant(&discordgo.Member{User: &discordgo.User{}}),
+		SurvivalWeights: cfg.Weights,
+		VictorCount:     cfg.VictorCount,
+	})
+
+	for _, id := range cfg.EntrantIDs {
+		p := NewParticipant(&discordgo.Member{User: &discordgo.User{ID: id, Username: id}})
+		g.participantMap[id] = p
+		g.participants = append(g.participants, p)
+	}
+
+	g.run(context.Background())
+	return g
+}
+
+type discardSender struct{}
+
+func (discardSender) Send(str string) (*discordgo.Message, error)       { return nil, nil }
+func (discardSender) SendQuoted(str string) (*discordgo.Message, error) { return nil, nil }
+func (discardSender) SendEmbed(str string) (*discordgo.Message, error)  { return nil, nil }
+func (discardSender) SendComplex(msg *discordgo.MessageSend) (*discordgo.Message, error) {
+	return nil, nil
+}
+func (discardSender) SendDM(user *discordgo.User, msg string) error { return nil }
+func (discardSender) SendDMComplex(user *discordgo.User, msg *discordgo.MessageSend) (*discordgo.Message, error) {
+	return nil, nil
+}
+func (discardSender) EditComplex(edit *discordgo.MessageEdit) (*discordgo.Message, error) {
+	return nil, nil
+}
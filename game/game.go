@@ -3,6 +3,7 @@ package game
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"strings"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/deadloct/bitheroes-hg-bot/lib"
 	"github.com/deadloct/bitheroes-hg-bot/settings"
+	"github.com/deadloct/bitheroes-hg-bot/storage"
 
 	"github.com/bwmarrin/discordgo"
 	log "github.com/sirupsen/logrus"
@@ -25,15 +27,9 @@ const (
 	Cancelled
 )
 
-// Temporary until persistance with DB and user settings panel.
-var NewGameSubscriptions = map[string]string{
-	"1058940133929394176": "1127437645627273236", // Test Server
-	"1066742525357989899": "1127443645231005796", // Bot Support Server
-	"608309926569181217":  "1124455074962354216", // The Bitverse
-}
-
 type PhraseGenerator interface {
-	GetRandomPhrase(user, mention string, alive []string) string
+	// GetRandomPhrase returns the phrase and the index in alive of the credited killer, or -1 for none.
+	GetRandomPhrase(user, mention string, alive []string) (string, int)
 }
 
 type JokeGenerator interface {
@@ -43,18 +39,35 @@ type JokeGenerator interface {
 type GameConfig struct {
 	Channel         *discordgo.Channel
 	Guild           *discordgo.Guild
+	ClaimDeadline   time.Duration // 0 disables the claim flow
 	DayDelay        time.Duration
 	Delay           time.Duration // delayed start
 	Clone           int
+	Emojis          map[settings.EmojiKey]settings.EmojiInfo // guild overrides of the global emojis
+	Eligibility     Eligibility
+	EntryMode       EntryMode // defaults to EntryReactions
 	JokeGenerator   JokeGenerator
+	Locale          string
 	MinimumTier     int
 	Notify          *discordgo.User
+	NotifyRole      string         // role pinged when the game starts
+	Pacing          PacingStrategy // defaults to ClassicPacing
 	PhraseGenerator PhraseGenerator
+	Prizes          []string        // ordered by placement, empty when the sponsor is the prize
+	ProvablyFair    bool            // publish a seed commitment in the intro and reveal it at the end
+	Randomizer      lib.Randomizer  // defaults to a SeededRandomizer using Seed
+	Reminders       []time.Duration // before entries close, pinging NotifyRole
+	Seed            uint64
 	Sender          Sender
 	Session         *discordgo.Session
 	Sponsor         string
 	StartedBy       *Participant
+	Store           storage.Store      // optional, finished games are recorded here
+	TargetDuration  time.Duration      // 0 uses DayDelay and Pacing as given
+	SurvivalWeights map[string]float64 // by user ID, set from Winners and Weighers when the game starts unless given
 	VictorCount     int
+	Weighers        []Weigher     // optional hooks that change the odds of surviving
+	Winners         *WinnerPolicy // optional
 }
 
 type Game struct {
@@ -62,8 +75,27 @@ type Game struct {
 
 	introMessage   *discordgo.Message
 	state          GameState
+	entrants       []*Participant // registration order, before clones were added
 	participants   []*Participant
 	participantMap map[string]*Participant
+	eliminations   []Elimination
+	awards         []Award
+	ranked         []ranked // strict ranking used to reroll unclaimed prizes
+	disqualified   []disqualification
+	claimStatus    *discordgo.Message
+	claimTimers    map[int]*time.Timer
+	rejected       map[string]struct{} // user IDs already told why they can't enter
+	createdAt      time.Time
+	startedAt      time.Time
+	finishedAt     time.Time
+	deadline       time.Time // when a game with a TargetDuration should end
+	entriesClose   time.Time
+	day            int                  // 1-based day being simulated, 0 before the first
+	introValues    settings.IntroValues // to redraw the intro as entries come in
+	rescheduled    chan struct{}        // wakes awaitStart when entriesClose moves
+	closing        bool                 // set once the start timer fires, entriesClose can't move after
+	resumed        chan struct{}        // non-nil while paused, closed on resume
+	pausedAt       time.Time
 
 	sync.Mutex
 }
@@ -73,44 +105,85 @@ func NewGame(cfg GameConfig) *Game {
 		cfg.DayDelay = settings.DefaultDayDelay
 	}
 
+	if cfg.Randomizer == nil {
+		cfg.Randomizer = lib.NewSeededRandomizer(cfg.Seed)
+	}
+
 	return &Game{
 		GameConfig:     cfg,
 		participantMap: make(map[string]*Participant),
+		claimTimers:    make(map[int]*time.Timer),
+		rejected:       make(map[string]struct{}),
+		rescheduled:    make(chan struct{}, 1),
+		createdAt:      time.Now(),
 	}
 }
 
 func (g *Game) Start(ctx context.Context) error {
-	participantEmoji := settings.GetEmoji(settings.EmojiParticipant)
-	effieEmoji := settings.GetEmoji(settings.EmojiEffie)
-	cloneEmoji := settings.GetEmoji(settings.EmojiClone)
+	participantEmoji := g.emoji(settings.EmojiParticipant)
+	effieEmoji := g.emoji(settings.EmojiEffie)
+	cloneEmoji := g.emoji(settings.EmojiClone)
 
 	// This is the welcome messsage that people react to to enter.
-	intro, err := g.getIntro(settings.IntroValues{
-		Delay:       g.Delay,
-		EntryEmoji:  participantEmoji.EmojiCode(),
-		EffieEmoji:  effieEmoji.EmojiCode(),
-		CloneEmoji:  cloneEmoji.EmojiCode(),
-		Clone:       g.Clone,
-		MinimumTier: g.MinimumTier,
-		Sponsor:     g.Sponsor,
-		VictorCount: g.VictorCount,
-	})
+	var commitment string
+	if g.ProvablyFair {
+		commitment = lib.SeedCommitment(g.Seed)
+		g.logMessage(log.InfoLevel, "provably fair game with seed commitment %v", commitment)
+	}
+
+	g.Lock()
+	g.entriesClose = time.Now().Add(g.Delay)
+	g.introValues = settings.IntroValues{
+		Commitment:   commitment,
+		Deadline:     g.entriesClose.Unix(),
+		Delay:        g.Delay,
+		Eligibility:  append(g.Eligibility.Rules(), g.Winners.Rules()...),
+		Weights:      g.weightRules(),
+		Pacing:       g.pacingRule(),
+		EntryButtons: g.EntryMode == EntryButtons,
+		EntryEmoji:   participantEmoji.EmojiCode(),
+		EffieEmoji:   effieEmoji.EmojiCode(),
+		CloneEmoji:   cloneEmoji.EmojiCode(),
+		Clone:        g.Clone,
+		MinimumTier:  g.MinimumTier,
+		TierEnforced: len(g.Eligibility.TierRoles) > 0,
+		Prizes:       g.introPrizes(),
+		Sponsor:      g.Sponsor,
+		VictorCount:  g.VictorCount,
+	}
+	vals := g.introValues
+	g.Unlock()
+
+	intro, err := g.getIntro(vals)
 	if err != nil {
 		return err
 	}
 
 	g.logMessage(log.DebugLevel, "sending intro")
-	if g.introMessage, err = g.Sender.SendEmbed(intro); err != nil {
+	if g.EntryMode == EntryButtons {
+		g.introMessage, err = g.Sender.SendComplex(&discordgo.MessageSend{
+			Embeds:     []*discordgo.MessageEmbed{{Description: intro}},
+			Components: entryButtons(),
+		})
+	} else {
+		g.introMessage, err = g.Sender.SendEmbed(intro)
+	}
+
+	if err != nil {
 		return err
 	}
 
-	if role, ok := NewGameSubscriptions[g.Guild.ID]; ok {
-		g.logMessage(log.InfoLevel, "sending game notification for role %v in guild %v (%v)", role, g.Guild.Name, g.Guild.ID)
-		g.Sender.Send(fmt.Sprintf("<@&%s>", role))
+	if g.NotifyRole != "" {
+		g.logMessage(log.InfoLevel, "sending game notification for role %v in guild %v (%v)", g.NotifyRole, g.Guild.Name, g.Guild.ID)
+		g.Sender.Send(fmt.Sprintf("<@&%s>", g.NotifyRole))
 	}
 
-	g.Session.MessageReactionAdd(g.introMessage.ChannelID, g.introMessage.ID,
-		fmt.Sprintf("%v:%v", participantEmoji.Name, participantEmoji.ID))
+	if g.EntryMode != EntryButtons {
+		if err := g.Session.MessageReactionAdd(g.introMessage.ChannelID, g.introMessage.ID,
+			fmt.Sprintf("%v:%v", participantEmoji.Name, participantEmoji.ID)); err != nil {
+			g.logMessage(log.ErrorLevel, "unable to react to the intro with %v, tributes must add the reaction themselves: %v", participantEmoji.EmojiCode(), err)
+		}
+	}
 
 	g.delayedStart(ctx)
 	return nil
@@ -131,43 +204,68 @@ func (g *Game) IsRunning() bool {
 	return g.state == Started || g.state == NotStarted
 }
 
+func (g *Game) IsFinished() bool {
+	g.Lock()
+	defer g.Unlock()
+
+	return g.state == Finished
+}
+
+// RegisterUser enters a tribute who reacted to the intro. It can DM them, so
+// it must not be called with the game's or the manager's lock held.
 func (g *Game) RegisterUser(messageID, emoji string, participant *Participant) {
 	g.logMessage(log.InfoLevel, "Registering user %v", participant.DisplayFullName())
 
-	if g.HasStarted() {
-		g.logMessage(log.InfoLevel, "Game already started, cannot register user %v", participant.DisplayFullName())
+	if !g.isEntryReaction(messageID, emoji) {
+		g.logMessage(log.InfoLevel, "User %v reacted with a different emoji or to a different message, not registering", participant.DisplayFullName())
 		return
 	}
 
-	participantEmoji := settings.GetEmoji(settings.EmojiParticipant)
-	if emoji != participantEmoji.Name {
-		g.logMessage(log.InfoLevel, "User %v reacted with a different emoji, not registering", participant.DisplayFullName())
+	if _, err := g.register(participant); err != nil {
+		g.logMessage(log.InfoLevel, "Not registering user %v: %v", participant.DisplayFullName(), err)
+
+		var ineligible *IneligibleError
+		if errors.As(err, &ineligible) {
+			g.notifyIneligible(participant, ineligible)
+		}
+
 		return
 	}
 
-	if messageID != g.introMessage.ID {
-		g.logMessage(log.InfoLevel, "User %v reacted to a different message, not registering", participant.DisplayFullName())
-		return
+	if g.Winners.Handicapped(participant.User.ID) {
+		g.notifyHandicap(participant)
 	}
+}
 
-	if participant.User.Bot {
-		g.logMessage(log.InfoLevel, "User %v is bot, not registering", participant.DisplayFullName())
+// WithdrawUser removes a user who took back their entry reaction. Once the game
+// has started their entry stands.
+func (g *Game) WithdrawUser(messageID, emoji, userID string) {
+	if !g.isEntryReaction(messageID, emoji) {
 		return
 	}
 
-	if _, ok := g.participantMap[participant.User.ID]; !ok {
-		g.participantMap[participant.User.ID] = participant
-		g.participants = append(g.participants, participant)
-		g.logMessage(log.InfoLevel, "Registered user %v", participant.DisplayFullName())
+	if _, err := g.withdraw(userID); err != nil {
+		g.logMessage(log.InfoLevel, "Not withdrawing user %v: %v", userID, err)
+	}
+}
 
-	} else {
-		g.logMessage(log.InfoLevel, "User %v already registered, not registering", participant.DisplayFullName())
+func (g *Game) isEntryReaction(messageID, emoji string) bool {
+	return g.EntryMode != EntryButtons && emoji == g.emoji(settings.EmojiParticipant).Name &&
+		g.introMessage != nil && messageID == g.introMessage.ID
+}
+
+// emoji returns the guild's emoji for key, or the global one.
+func (g *Game) emoji(key settings.EmojiKey) settings.EmojiInfo {
+	if e, ok := g.Emojis[key]; ok {
+		return e
 	}
+
+	return settings.GetEmoji(key)
 }
 
 func (g *Game) getIntro(vals settings.IntroValues) (string, error) {
 	var result bytes.Buffer
-	if err := settings.Intro.Execute(&result, vals); err != nil {
+	if err := settings.Intro(g.Locale).Execute(&result, vals); err != nil {
 		return "", err
 	}
 
@@ -178,32 +276,29 @@ func (g *Game) delayedStart(ctx context.Context) {
 	g.logMessage(log.InfoLevel, "delaying start by %v", g.Delay)
 
 	jokeCh := make(chan struct{}, 1)
-	NewJester(g.JokeGenerator, g.Sender, g.Session).StartRandomJokes(ctx, jokeCh)
-
-	go func() {
-		select {
-		case <-ctx.Done():
-			jokeCh <- struct{}{}
-			g.logMessage(log.InfoLevel, "context done, cancelling game")
-			g.Lock()
-			g.state = Cancelled
-			g.Unlock()
+	NewJester(g.JokeGenerator, g.Sender, g.Session, g.emoji(settings.EmojiCaesar)).StartRandomJokes(ctx, jokeCh)
 
-		case <-time.After(g.Delay):
-			g.logMessage(log.InfoLevel, "delay timer ended, sending msg to joke ch to end the jester")
-			jokeCh <- struct{}{}
-			g.logMessage(log.InfoLevel, "starting game...")
-			g.run(ctx)
-		}
-	}()
+	go g.awaitStart(ctx, jokeCh)
 }
 
 func (g *Game) run(ctx context.Context) []*Participant {
-	g.logMessage(log.InfoLevel, "starting game with user count %v", len(g.participants))
+	// The entrants are taken in the same critical section that closes
+	// registration so /hg-status and /hg-participants never see a started game
+	// without them.
 	g.Lock()
 	g.state = Started
+	g.startedAt = time.Now()
+	g.entrants = append([]*Participant(nil), g.participants...)
+	if g.SurvivalWeights == nil && len(g.entrants) > 0 {
+		g.SurvivalWeights = g.survivalWeights(g.entrants)
+	}
 	g.Unlock()
 
+	// Registration is closed, so only run changes participants from here on.
+	g.logMessage(log.InfoLevel, "starting game with user count %v", len(g.participants))
+
+	defer g.saveRecord()
+
 	if len(g.participants) == 0 {
 		g.logMessage(log.InfoLevel, "no users entered")
 		g.Sender.SendQuoted(fmt.Sprintf("No tributes have come forward within %v. This district will be eliminated.", g.Delay))
@@ -213,10 +308,12 @@ func (g *Game) run(ctx context.Context) []*Participant {
 		return nil
 	}
 
+	g.planDuration()
 	g.sendTributeOutput(g.participants)
 
 	// Clone tributes
 	if g.Clone > 1 {
+		g.Lock()
 		for _, p := range g.participants {
 			for i := 2; i <= g.Clone; i++ {
 				pclone := NewParticipant(p.Member)
@@ -224,11 +321,17 @@ func (g *Game) run(ctx context.Context) []*Participant {
 				g.participants = append(g.participants, pclone)
 			}
 		}
+		g.Unlock()
 	}
 
 	var quietDays int
 	for day := 0; len(g.participants) > g.VictorCount; day++ {
-		time.Sleep(g.DayDelay)
+		select {
+		case <-ctx.Done():
+		case <-time.After(g.dayDelay(day)):
+		}
+
+		g.waitWhilePaused(ctx)
 
 		select {
 		case <-ctx.Done():
@@ -240,7 +343,9 @@ func (g *Game) run(ctx context.Context) []*Participant {
 
 		default:
 			g.logMessage(log.InfoLevel, "simulating day %v with %v tributes", day, len(g.participants))
-			var err error
+			g.Lock()
+			g.day = day + 1
+			g.Unlock()
 
 			var mustKill bool
 			if quietDays >= settings.MaxQuietDays {
@@ -248,7 +353,7 @@ func (g *Game) run(ctx context.Context) []*Participant {
 			}
 
 			pcount := len(g.participants)
-			g.participants, err = g.runDay(ctx, day, g.participants, mustKill)
+			living, err := g.runDay(ctx, day, g.participants, mustKill)
 			if err != nil {
 				g.logMessage(log.ErrorLevel, "failed to simulate day %v: %v", day, err)
 				g.Sender.SendQuoted(fmt.Sprintf("failed to run game for day %v", day+1))
@@ -258,6 +363,10 @@ func (g *Game) run(ctx context.Context) []*Participant {
 				return nil
 			}
 
+			g.Lock()
+			g.participants = living
+			g.Unlock()
+
 			if len(g.participants) == pcount {
 				quietDays++
 			} else {
@@ -268,6 +377,22 @@ func (g *Game) run(ctx context.Context) []*Participant {
 		}
 	}
 
+	g.Lock()
+	g.state = Finished
+	g.finishedAt = time.Now()
+	dropped := g.resumed != nil // a pause during the last day has nothing left to hold
+	g.resumed = nil
+	g.Unlock()
+
+	if dropped {
+		g.logMessage(log.InfoLevel, "dropping the pause, the last day has ended the game")
+		g.Sender.SendQuoted("The Gamemakers' pause came too late, the final day had already begun. The arena will not be paused.")
+	}
+
+	if err := g.awardPrizes(); err != nil {
+		g.logMessage(log.ErrorLevel, "failed to award prizes: %v", err)
+	}
+
 	var mentions []string
 	var winnerLogs []string
 	for _, p := range g.participants {
@@ -278,8 +403,8 @@ func (g *Game) run(ctx context.Context) []*Participant {
 	g.logMessage(log.InfoLevel, fmt.Sprintf("Winners for sponsor %v: %v", g.Sponsor, strings.Join(winnerLogs, ",")))
 
 	mentionStr := strings.Join(mentions, ", ")
-	snow := settings.GetEmoji(settings.EmojiPresSnow)
-	host := settings.GetEmoji(settings.EmojiCaesar)
+	snow := g.emoji(settings.EmojiPresSnow)
+	host := g.emoji(settings.EmojiCaesar)
 
 	// TODO: replace with a pluralizing library
 	winnerStr := "winner"
@@ -295,8 +420,23 @@ func (g *Game) run(ctx context.Context) []*Participant {
 		fmt.Sprintf("%v  This year's Hunger Games have concluded. Congratulations to our new %v: %v!", host.EmojiCode(), victorStr, mentionStr),
 		settings.WhiteSpaceChar,
 		fmt.Sprintf("%v  The tributes all demonstrated exceptional survival skills but the %s emerged victorious. Their combat prowess is a testament to the superiority of the Capitol's training and preparation methods.", snow.EmojiCode(), winnerStr),
-		settings.WhiteSpaceChar,
-		fmt.Sprintf("The %s won **%s**!", victorHasStr, g.Sponsor),
+	}
+
+	if len(g.Prizes) > 0 {
+		lines = append(lines, settings.WhiteSpaceChar, fmt.Sprintf("Prizes sponsored by **%s**:", g.Sponsor))
+		for _, a := range g.Awards() {
+			lines = append(lines, fmt.Sprintf(
+				"• %v goes to %v (%v place)",
+				g.prizeLabel(a), a.Participant.Mention(), lib.Ordinal(a.Place),
+			))
+		}
+	} else {
+		lines = append(lines, settings.WhiteSpaceChar, fmt.Sprintf("The %s won **%s**!", victorHasStr, g.Sponsor))
+	}
+
+	if standings := g.Standings(); len(standings) > 1 {
+		lines = append(lines, settings.WhiteSpaceChar, "**Final standings:**")
+		lines = append(lines, formatStandings(standings, (*Participant).DisplayName)...)
 	}
 
 	if g.Notify != nil {
@@ -309,9 +449,9 @@ func (g *Game) run(ctx context.Context) []*Participant {
 
 	g.sendBatchOutput(lines)
 
-	g.Lock()
-	g.state = Finished
-	g.Unlock()
+	if g.ProvablyFair {
+		g.sendReveal()
+	}
 
 	g.sendFinalNotifications(g.StartedBy, g.participants)
 
@@ -333,30 +473,17 @@ func (g *Game) runDay(ctx context.Context, day int, participants []*Participant,
 		settings.WhiteSpaceChar,
 	}
 
-	// min and max are 0-based
-	var min int
-	if mustKill {
+	// min and max are 0-based, max is exclusive
+	min, max := g.pacing().KillRange(day, len(participants), g.VictorCount)
+	if mustKill && min < 1 {
 		g.logMessage(log.InfoLevel, "forcing a kill due to too many quiet days")
 		min = 1
 	}
 
-	max := int(math.Min(
-		float64(len(participants)/2),
-		float64(len(participants)-g.VictorCount),
-	))
-	max++ // +1 b/c right is exclusive
-
-	// 1/2 - 3/4 on the first day, it's always a slaughter!
-	if day == 0 && len(participants) > 5 {
-		min = max / 2
-		max = int(math.Min(
-			float64(max*3/4),
-			float64(len(participants)-g.VictorCount),
-		))
-		max++ // +1 b/c right is exclusive
-	}
+	max = int(math.Min(float64(max), float64(len(participants)-g.VictorCount+1)))
+	min = int(math.Min(float64(min), float64(max-1)))
 
-	killCount, err := lib.GetRandomInt(min, max)
+	killCount, err := g.Randomizer.GetRandomInt(min, max)
 	if err != nil {
 		g.logMessage(log.ErrorLevel, "failed to get random number between %v and %v: %v", min, max, err)
 		return nil, err
@@ -368,20 +495,16 @@ func (g *Game) runDay(ctx context.Context, day int, participants []*Participant,
 		return participants, nil
 	}
 
-	dead := make(map[int]struct{})
-	for i := 0; i < killCount; i++ {
-		for {
-			toDie, err := lib.GetRandomInt(0, len(participants))
-			if err != nil {
-				g.logMessage(log.ErrorLevel, "failed to get random number between %v and %v: %v", min, max, err)
-				return nil, err
-			}
+	// deadOrder keeps the order of the draw so that replays narrate deaths identically.
+	deadOrder, err := g.drawDead(participants, killCount)
+	if err != nil {
+		g.logMessage(log.ErrorLevel, "failed to draw %v deaths: %v", killCount, err)
+		return nil, err
+	}
 
-			if _, alreadyDead := dead[toDie]; !alreadyDead {
-				dead[toDie] = struct{}{}
-				break
-			}
-		}
+	dead := make(map[int]struct{})
+	for _, i := range deadOrder {
+		dead[i] = struct{}{}
 	}
 
 	var living []*Participant
@@ -394,7 +517,7 @@ func (g *Game) runDay(ctx context.Context, day int, participants []*Participant,
 	}
 
 	var deadNames []string
-	for i := range dead {
+	for _, i := range deadOrder {
 		deadNames = append(deadNames, participants[i].DisplayName())
 
 		mention := ""
@@ -402,14 +525,23 @@ func (g *Game) runDay(ctx context.Context, day int, participants []*Participant,
 			mention = participants[i].Mention()
 		}
 
-		line := "• " + g.PhraseGenerator.GetRandomPhrase(participants[i].DisplayName(), mention, livingNames)
-		g.logMessage(log.TraceLevel, "Day %v: %v", day, line)
-		output = append(output, line)
+		phrase, killerNum := g.PhraseGenerator.GetRandomPhrase(participants[i].DisplayName(), mention, livingNames)
+		g.logMessage(log.TraceLevel, "Day %v: %v", day, phrase)
+		output = append(output, "• "+phrase)
+
+		var killer *Participant
+		if killerNum >= 0 && killerNum < len(living) {
+			killer = living[killerNum]
+		}
+
+		g.Lock()
+		g.eliminations = append(g.eliminations, Elimination{Day: day, Participant: participants[i], Killer: killer, Phrase: phrase})
+		g.Unlock()
 	}
 
 	g.logMessage(log.DebugLevel, "Dead players after day %v: %v", day+1, strings.Join(deadNames, ", "))
 
-	host := settings.GetEmoji(settings.EmojiCaesar)
+	host := g.emoji(settings.EmojiCaesar)
 	output = append(output, settings.WhiteSpaceChar, fmt.Sprintf(
 		"%v  %v player(s) remain at the end of day %v: %v",
 		host.EmojiCode(),
@@ -428,7 +560,7 @@ func (g *Game) runDay(ctx context.Context, day int, participants []*Participant,
 }
 
 func (g *Game) sendTributeOutput(participants []*Participant) {
-	hostEmoji := settings.GetEmoji(settings.EmojiCaesar)
+	hostEmoji := g.emoji(settings.EmojiCaesar)
 	g.logMessage(log.DebugLevel, "tribute count: %v", len(participants))
 
 	tributeLines := []string{
@@ -445,7 +577,7 @@ func (g *Game) sendTributeOutput(participants []*Participant) {
 	tributeLines = append(tributeLines, strings.Join(tributes, ", "))
 
 	if g.Clone > 1 {
-		cloneEmoji := settings.GetEmoji(settings.EmojiClone)
+		cloneEmoji := g.emoji(settings.EmojiClone)
 		cloneCode := cloneEmoji.EmojiCode()
 		tributeLines = append(tributeLines, settings.WhiteSpaceChar, fmt.Sprintf(
 			"%s   **MEGA HG MODE ACTIVATED -- TRIBUTES WILL BE CLONED %v TIMES**   %v",
@@ -460,11 +592,26 @@ func (g *Game) sendBatchOutput(lines []string) {
 }
 
 func (g *Game) sendFinalNotifications(startedBy *Participant, winners []*Participant) {
+	awards := g.Awards()
+
 	if startedBy.User != nil {
 		var msg string
-		if len(winners) == 0 {
+		switch {
+		case len(winners) == 0:
 			msg = "Your Hunger Games event has finished but sadly there were no participants."
-		} else {
+
+		case len(g.Prizes) > 0:
+			var users []string
+			for _, a := range awards {
+				users = append(users, fmt.Sprintf("* %v: %s", g.prizeLabel(a), a.Participant.DisplayFullName()))
+			}
+
+			msg = fmt.Sprintf(
+				"Your Hunger Games event has finished! Please contact the following prize winners:\n%s",
+				strings.Join(users, "\n"),
+			)
+
+		default:
 			var users []string
 			for _, u := range winners {
 				users = append(users, fmt.Sprintf("* %s", u.DisplayFullName()))
@@ -476,12 +623,39 @@ func (g *Game) sendFinalNotifications(startedBy *Participant, winners []*Partici
 			)
 		}
 
-		if err := g.Sender.SendDM(startedBy.User, msg); err != nil {
+		if standings := g.Standings(); len(winners) > 0 && len(standings) > 1 {
+			msg = fmt.Sprintf(
+				"%s\n\nFinal standings, in case you'd like to award runners-up:\n%s",
+				msg,
+				strings.Join(formatStandings(standings, (*Participant).DisplayFullName), "\n"),
+			)
+		}
+
+		if err := g.Sender.SendDM(startedBy.User, lib.Truncate(msg, settings.DiscordMaxMsgLen)); err != nil {
 			g.logMessage(log.ErrorLevel, "unable to create DM with startedBy %s for winner notification: %v", startedBy.DisplayFullName(), err)
-			return
 		}
 	}
 
+	if g.ClaimDeadline > 0 {
+		g.startClaims()
+		return
+	}
+
+	if len(awards) > 0 {
+		for _, a := range awards {
+			msg := fmt.Sprintf(
+				"Congratulations, you placed %v in the Hunger Games event hosted by %s and won %v! Please get in touch with them if you haven't already.",
+				lib.Ordinal(a.Place), startedBy.DisplayFullName(), g.prizeLabel(a),
+			)
+
+			if err := g.Sender.SendDM(a.Participant.User, msg); err != nil {
+				g.logMessage(log.ErrorLevel, "unable to create DM to participant %s for prize notification: %v", a.Participant.DisplayFullName(), err)
+			}
+		}
+
+		return
+	}
+
 	for _, winner := range winners {
 		if winner.User != nil {
 			msg := fmt.Sprintf(
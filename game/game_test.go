@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"github.com/bwmarrin/discordgo"
 	"github.com/deadloct/bitheroes-hg-bot/lib"
 	"github.com/deadloct/bitheroes-hg-bot/settings"
+	"github.com/deadloct/bitheroes-hg-bot/storage"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -35,23 +37,58 @@ func testSetupGameRun(f Fataler, userCount, multiplier int) (PhraseGenerator, []
 		}
 	}
 
-	return lib.NewJSONPhrases(data), members
+	return lib.NewJSONPhrases(data, nil), members
 }
 
-func testRunGame(f Fataler, cfg GameConfig, members []*discordgo.Member) []*Participant {
-	f.Helper()
+// testGame returns a game whose intro was sent as message "123" in channel and
+// guild "123", filling in the fields cfg leaves unset. Days don't wait.
+func testGame(cfg GameConfig) *Game {
+	if cfg.Channel == nil {
+		cfg.Channel = &discordgo.Channel{ID: "123", Name: "123"}
+	}
 
-	sender := &BufferSender{SendLatency: 100 * time.Millisecond}
+	if cfg.Guild == nil {
+		cfg.Guild = &discordgo.Guild{ID: "123", Name: "123"}
+	}
+
+	if cfg.DayDelay == 0 {
+		cfg.DayDelay = time.Nanosecond
+	}
+
+	if cfg.Sender == nil {
+		cfg.Sender = &BufferSender{}
+	}
+
+	if cfg.Sponsor == "" {
+		cfg.Sponsor = "Sponsor"
+	}
+
+	if cfg.StartedBy == nil {
+		cfg.StartedBy = NewParticipant(&discordgo.Member{User: &discordgo.User{ID: "123", Username: "Hello"}})
+	}
 
-	cfg.Sender = sender
 	g := NewGame(cfg)
 	g.introMessage = &discordgo.Message{ID: "123"}
+	return g
+}
 
+// testEnter has every member react to the intro of a testGame.
+func testEnter(g *Game, members []*discordgo.Member) {
 	emoji := settings.GetEmoji(settings.EmojiParticipant).Name
-	for i := 0; i < len(members); i++ {
-		g.RegisterUser("123", emoji, NewParticipant(members[i]))
+	for _, m := range members {
+		g.RegisterUser("123", emoji, NewParticipant(m))
+	}
+}
+
+func testRunGame(f Fataler, cfg GameConfig, members []*discordgo.Member) []*Participant {
+	f.Helper()
+
+	if cfg.Sender == nil {
+		cfg.Sender = &BufferSender{SendLatency: 100 * time.Millisecond}
 	}
 
+	g := testGame(cfg)
+	testEnter(g, members)
 	return g.run(context.Background())
 }
 
@@ -101,28 +138,12 @@ func TestGame_Run(t *testing.T) {
 			for i := 0; i < test.RunCount; i++ {
 				jp, users := testSetupGameRun(t, test.UserCount, test.Clone)
 
-				cfg := GameConfig{
-					Channel:         &discordgo.Channel{ID: "123", Name: "123"},
-					Guild:           &discordgo.Guild{ID: "123", Name: "123"},
-					DayDelay:        1 * time.Nanosecond,
+				victors := testRunGame(t, GameConfig{
 					PhraseGenerator: jp,
-					Session:         &discordgo.Session{},
-					Sponsor:         "Sponsor",
+					Sender:          sender,
 					Clone:           test.Clone,
-					StartedBy: NewParticipant(&discordgo.Member{
-						User: &discordgo.User{
-							ID:       "123",
-							Username: "Hello",
-						},
-					}),
-					VictorCount: test.Victors,
-				}
-
-				cfg.Sender = sender
-				g := NewGame(cfg)
-				g.introMessage = &discordgo.Message{ID: "123"}
-
-				victors := testRunGame(t, cfg, users)
+					VictorCount:     test.Victors,
+				}, users)
 
 				if len(victors) != test.Victors {
 					t.Fatalf("expected %v victors but got %v", test.Victors, len(victors))
@@ -133,6 +154,572 @@ func TestGame_Run(t *testing.T) {
 
 }
 
+func TestGame_Run_SameSeedIsReplayable(t *testing.T) {
+	log.SetLevel(log.WarnLevel)
+	data, err := os.ReadFile(path.Join("..", settings.DataLocation, "phrases.en.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, members := testSetupGameRun(t, 50, 1)
+
+	runWithSeed := func(seed uint64) []string {
+		rng := lib.NewSeededRandomizer(seed)
+		sender := &BufferSender{}
+		g := testGame(GameConfig{
+			PhraseGenerator: lib.NewJSONPhrases(data, rng),
+			Randomizer:      rng,
+			Seed:            seed,
+			Sender:          sender,
+			VictorCount:     3,
+		})
+
+		testEnter(g, members)
+
+		g.run(context.Background())
+		return sender.buffer
+	}
+
+	first := runWithSeed(1234)
+	second := runWithSeed(1234)
+	if strings.Join(first, "\n") != strings.Join(second, "\n") {
+		t.Fatal("expected identical game output for the same seed")
+	}
+}
+
+func TestReplay_MatchesLiveGame(t *testing.T) {
+	log.SetLevel(log.WarnLevel)
+	data, err := os.ReadFile(path.Join("..", settings.DataLocation, "phrases.en.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, members := testSetupGameRun(t, 40, 1)
+	rng := lib.NewSeededRandomizer(99)
+	g := testGame(GameConfig{
+		PhraseGenerator: lib.NewJSONPhrases(data, rng),
+		Prizes:          []string{"Gold", "Silver", "Bronze"},
+		ProvablyFair:    true,
+		Randomizer:      rng,
+		Seed:            99,
+		Clone:           2,
+		VictorCount:     2,
+	})
+
+	testEnter(g, members)
+
+	live := g.run(context.Background())
+	replayed := Replay(ReplayConfig{
+		Clone:       2,
+		EntrantIDs:  ParseEntrantsFile(EntrantsFile(g.entrants)),
+		PhraseData:  data,
+		PrizeCount:  len(g.Prizes),
+		Seed:        99,
+		VictorCount: 2,
+	})
+
+	if len(live) != len(replayed.participants) {
+		t.Fatalf("expected %v replayed victors but got %v", len(live), len(replayed.participants))
+	}
+
+	for i := range live {
+		if live[i].User.ID != replayed.participants[i].User.ID {
+			t.Errorf("victor %v: expected %v but replay produced %v", i, live[i].User.ID, replayed.participants[i].User.ID)
+		}
+	}
+
+	liveAwards, replayedAwards := g.Awards(), replayed.Awards()
+	if len(liveAwards) != 3 || len(replayedAwards) != 3 {
+		t.Fatalf("expected 3 awards live and replayed but got %v and %v", len(liveAwards), len(replayedAwards))
+	}
+
+	for i := range liveAwards {
+		if liveAwards[i].Participant.User.ID != replayedAwards[i].Participant.User.ID {
+			t.Errorf("award %v: expected %v but replay produced %v", i, liveAwards[i].Participant.User.ID, replayedAwards[i].Participant.User.ID)
+		}
+	}
+}
+
+func TestGame_Run_RecordsHistory(t *testing.T) {
+	jp, members := testSetupGameRun(t, 30, 1)
+	store := storage.NewMemoryStore()
+
+	victors := testRunGame(t, GameConfig{
+		Guild:           &discordgo.Guild{ID: "guild", Name: "123"},
+		PhraseGenerator: jp,
+		Store:           store,
+		VictorCount:     2,
+	}, members)
+
+	recs, total, err := store.ListGames("guild", 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if total != 1 {
+		t.Fatalf("expected 1 recorded game but got %v", total)
+	}
+
+	rec := recs[0]
+	if rec.State != storage.GameFinished {
+		t.Errorf("expected a finished game but got %v", rec.State)
+	}
+
+	if len(rec.Entrants) != 30 || len(rec.Victors) != len(victors) {
+		t.Errorf("expected 30 entrants and %v victors but got %v and %v", len(victors), len(rec.Entrants), len(rec.Victors))
+	}
+
+	if len(rec.Eliminations)+len(rec.Victors) != len(rec.Entrants) {
+		t.Errorf("expected every entrant to be eliminated or victorious, got %v eliminations", len(rec.Eliminations))
+	}
+}
+
+func TestGame_Standings(t *testing.T) {
+	jp, members := testSetupGameRun(t, 40, 1)
+	sender := &BufferSender{}
+	g := testGame(GameConfig{
+		PhraseGenerator: jp,
+		Sender:          sender,
+		VictorCount:     3,
+	})
+
+	testEnter(g, members)
+
+	victors := g.run(context.Background())
+	standings := g.Standings()
+
+	if standings[0].Place != 1 || len(standings[0].Participants) != len(victors) {
+		t.Fatalf("expected the %v victors to share 1st place, got %+v", len(victors), standings[0])
+	}
+
+	ranked := 0
+	for _, s := range standings {
+		if s.Place != ranked+1 {
+			t.Errorf("expected place %v after %v ranked tributes but got %v", ranked+1, ranked, s.Place)
+		}
+
+		ranked += len(s.Participants)
+	}
+
+	if ranked != len(members) {
+		t.Errorf("expected all %v tributes in the standings but got %v", len(members), ranked)
+	}
+}
+
+func TestGame_Run_AnnouncesPrizes(t *testing.T) {
+	jp, members := testSetupGameRun(t, 20, 1)
+	sender := &BufferSender{}
+	prizes := []string{"Gold", "Silver", "Bronze"}
+	g := testGame(GameConfig{
+		PhraseGenerator: jp,
+		Prizes:          prizes,
+		Sender:          sender,
+		VictorCount:     1,
+	})
+
+	testEnter(g, members)
+	victors := g.run(context.Background())
+
+	awards := g.Awards()
+	if len(awards) != len(prizes) {
+		t.Fatalf("expected %v awards but got %+v", len(prizes), awards)
+	}
+
+	if awards[0].Place != 1 || awards[0].Participant != victors[0] {
+		t.Errorf("expected the victor to win the 1st prize but got %+v", awards[0])
+	}
+
+	var victory string
+	for _, msg := range sender.buffer {
+		if strings.Contains(msg, "Prizes sponsored by") {
+			victory = msg
+		}
+	}
+
+	for i, a := range awards {
+		if a.Prize != prizes[i] || (i > 0 && a.Place < awards[i-1].Place) {
+			t.Errorf("expected prize %v to be %v and go down the standings but got %+v", i, prizes[i], a)
+		}
+
+		line := fmt.Sprintf("• %v prize: **%v** goes to %v (%v place)", lib.Ordinal(i+1), prizes[i], a.Participant.Mention(), lib.Ordinal(a.Place))
+		if !strings.Contains(victory, line) {
+			t.Errorf("expected the victory message to contain %q but got %v", line, victory)
+		}
+
+		var dms []string
+		for j, id := range sender.dmUsers {
+			if id == a.Participant.User.ID {
+				dms = append(dms, sender.dms[j])
+			}
+		}
+
+		want := fmt.Sprintf("you placed %v in the Hunger Games event hosted by Hello (Hello) and won %v prize: **%v**!", lib.Ordinal(a.Place), lib.Ordinal(i+1), prizes[i])
+		if len(dms) != 1 || !strings.Contains(dms[0], want) {
+			t.Errorf("expected %v to be DMed %q once but got %v", a.Participant.User.ID, want, dms)
+		}
+	}
+}
+
+func TestGame_Claims_RerollUnclaimed(t *testing.T) {
+	jp, members := testSetupGameRun(t, 20, 1)
+	g := testGame(GameConfig{
+		ClaimDeadline:   200 * time.Millisecond,
+		PhraseGenerator: jp,
+		Prizes:          []string{"Gold", "Silver"},
+		VictorCount:     1,
+	})
+
+	testEnter(g, members)
+
+	g.run(context.Background())
+	awards := g.Awards()
+	if len(awards) != 2 || awards[0].Status != ClaimPending {
+		t.Fatalf("expected 2 pending awards but got %+v", awards)
+	}
+
+	if _, err := g.Claim(awards[0].Participant.User.ID, 1); err != ErrClaimNotYours {
+		t.Errorf("expected ErrClaimNotYours when claiming somebody else's prize but got %v", err)
+	}
+
+	if _, err := g.Claim(awards[0].Participant.User.ID, 0); err != nil {
+		t.Fatalf("expected the 1st prize to be claimed but got %v", err)
+	}
+
+	time.Sleep(300 * time.Millisecond)
+
+	awards = g.Awards()
+	if len(awards) != 3 {
+		t.Fatalf("expected the unclaimed 2nd prize to be rerolled but got %+v", awards)
+	}
+
+	if awards[0].Status != ClaimClaimed || awards[1].Status != ClaimExpired {
+		t.Errorf("expected claimed and expired awards but got %v and %v", awards[0].Status, awards[1].Status)
+	}
+
+	rerolled := awards[2]
+	if rerolled.Slot != 1 || rerolled.Participant.User.ID == awards[0].Participant.User.ID || rerolled.Participant.User.ID == awards[1].Participant.User.ID {
+		t.Errorf("expected the 2nd prize to pass to a new tribute but got %+v", rerolled)
+	}
+}
+
+func TestGame_Claims_Restored(t *testing.T) {
+	jp, members := testSetupGameRun(t, 20, 1)
+	g := testGame(GameConfig{
+		ClaimDeadline:   time.Hour,
+		PhraseGenerator: jp,
+		Prizes:          []string{"Gold", "Silver"},
+		VictorCount:     1,
+	})
+
+	testEnter(g, members)
+	g.run(context.Background())
+
+	rec := g.Record()
+	if len(rec.Awards) != 2 || rec.Awards[0].Deadline.IsZero() {
+		t.Fatalf("expected the record to keep the claim deadlines but got %+v", rec.Awards)
+	}
+
+	// The 2nd prize's deadline passed while the bot was down.
+	rec.Awards[1].Deadline = time.Now().Add(-time.Minute)
+
+	sender := &BufferSender{}
+	restored := restoreGame(rec, GameConfig{
+		Channel: &discordgo.Channel{ID: "123"},
+		Guild:   &discordgo.Guild{ID: "123"},
+		Sender:  sender,
+	})
+	restored.resumeClaims()
+
+	awards := restored.Awards()
+	if len(awards) != 3 || awards[1].Status != ClaimExpired || awards[2].Slot != 1 || awards[2].Status != ClaimPending {
+		t.Fatalf("expected the overdue 2nd prize to expire and pass on but got %+v", awards)
+	}
+
+	if _, err := restored.Claim(awards[0].Participant.User.ID, 0); err != nil {
+		t.Errorf("expected the 1st prize to still be claimable after restoring but got %v", err)
+	}
+
+	if !restored.HasPendingClaims() {
+		t.Error("expected the rerolled 2nd prize to wait for its new winner")
+	}
+}
+
+func TestGame_WithdrawUser(t *testing.T) {
+	jp, members := testSetupGameRun(t, 3, 1)
+	g := testGame(GameConfig{
+		PhraseGenerator: jp,
+		VictorCount:     1,
+	})
+
+	emoji := settings.GetEmoji(settings.EmojiParticipant).Name
+	testEnter(g, members)
+
+	g.WithdrawUser("456", emoji, members[0].User.ID)
+	if len(g.participants) != 3 {
+		t.Fatalf("expected reactions on other messages to be ignored but got %v participants", len(g.participants))
+	}
+
+	g.WithdrawUser("123", emoji, members[0].User.ID)
+	if _, ok := g.participantMap[members[0].User.ID]; ok || len(g.participants) != 2 {
+		t.Fatalf("expected the withdrawn user to be removed but got %v participants", len(g.participants))
+	}
+
+	g.RegisterUser("123", emoji, NewParticipant(members[0]))
+	if len(g.participants) != 3 {
+		t.Fatalf("expected the user to be able to enter again but got %v participants", len(g.participants))
+	}
+
+	g.run(context.Background())
+
+	victor := g.participants[0]
+	g.WithdrawUser("123", emoji, victor.User.ID)
+	if len(g.participants) != 1 || g.participants[0] != victor {
+		t.Errorf("expected entries to stand once the game started but got %v", g.participants)
+	}
+}
+
+func TestGame_EntryButtons(t *testing.T) {
+	jp, members := testSetupGameRun(t, 3, 1)
+	g := testGame(GameConfig{
+		EntryMode:       EntryButtons,
+		PhraseGenerator: jp,
+		VictorCount:     1,
+	})
+
+	g.RegisterUser("123", settings.GetEmoji(settings.EmojiParticipant).Name, NewParticipant(members[0]))
+	if len(g.participants) != 0 {
+		t.Fatalf("expected reactions to be ignored in button mode but got %v participants", len(g.participants))
+	}
+
+	for i, m := range members {
+		if count, err := g.Enter("123", NewParticipant(m)); err != nil || count != i+1 {
+			t.Fatalf("expected %v tributes but got %v, %v", i+1, count, err)
+		}
+	}
+
+	if _, err := g.Enter("123", NewParticipant(members[0])); err != ErrAlreadyEntered {
+		t.Errorf("expected ErrAlreadyEntered but got %v", err)
+	}
+
+	if _, err := g.Enter("456", NewParticipant(members[0])); err != ErrEntriesClosed {
+		t.Errorf("expected buttons on an old intro to be closed but got %v", err)
+	}
+
+	if count, err := g.Withdraw("123", members[0].User.ID); err != nil || count != 2 {
+		t.Errorf("expected 2 tributes after withdrawing but got %v, %v", count, err)
+	}
+
+	if _, err := g.Withdraw("123", members[0].User.ID); err != ErrNotEntered {
+		t.Errorf("expected ErrNotEntered but got %v", err)
+	}
+
+	g.run(context.Background())
+
+	if _, err := g.Enter("123", NewParticipant(members[0])); err != ErrEntriesClosed {
+		t.Errorf("expected entries to be closed once the game started but got %v", err)
+	}
+}
+
+func TestGame_RegisterUser_Ineligible(t *testing.T) {
+	sender := &BufferSender{}
+	g := testGame(GameConfig{
+		Eligibility: Eligibility{RequiredRoles: []string{"players"}},
+		Sender:      sender,
+	})
+
+	emoji := settings.GetEmoji(settings.EmojiParticipant).Name
+	outsider := &discordgo.Member{User: &discordgo.User{ID: "1", Username: "outsider"}}
+	g.RegisterUser("123", emoji, NewParticipant(outsider))
+	g.RegisterUser("123", emoji, NewParticipant(outsider))
+	g.RegisterUser("123", emoji, NewParticipant(&discordgo.Member{User: &discordgo.User{ID: "2", Username: "player"}, Roles: []string{"players"}}))
+
+	if len(g.participants) != 1 || g.participants[0].User.ID != "2" {
+		t.Errorf("expected only the player to enter but got %v", g.participants)
+	}
+
+	if len(sender.dms) != 1 || !strings.Contains(sender.dms[0], "<@&players>") {
+		t.Errorf("expected the outsider to be told once which role they need but got %v", sender.dms)
+	}
+}
+
+func TestManager_ReactionHandler_DMsWithoutLock(t *testing.T) {
+	m := &Manager{games: make(map[string]*RunningGame), archive: make(map[string]*Game)}
+	var locked bool
+	sender := &BufferSender{OnDM: func() {
+		if !m.TryLock() {
+			locked = true
+			return
+		}
+
+		m.Unlock()
+	}}
+
+	g := testGame(GameConfig{
+		Eligibility: Eligibility{RequiredRoles: []string{"players"}},
+		Sender:      sender,
+	})
+	m.games["123"] = &RunningGame{Game: g}
+
+	m.ReactionHandler(nil, &discordgo.MessageReactionAdd{
+		MessageReaction: &discordgo.MessageReaction{ChannelID: "123", MessageID: "123", Emoji: discordgo.Emoji{Name: settings.GetEmoji(settings.EmojiParticipant).Name}},
+		Member:          &discordgo.Member{User: &discordgo.User{ID: "1", Username: "outsider"}},
+	})
+
+	if len(sender.dms) != 1 || locked {
+		t.Errorf("expected the outsider to be told why without holding the manager's lock but got %v, locked %v", sender.dms, locked)
+	}
+}
+
+func TestFetchAllReactions(t *testing.T) {
+	var users []*discordgo.User
+	for i := 0; i < 250; i++ {
+		users = append(users, &discordgo.User{ID: fmt.Sprintf("%03d", i)})
+	}
+
+	var requests int
+	all, err := fetchAllReactions(func(after string) ([]*discordgo.User, error) {
+		requests++
+		start := 0
+		if after != "" {
+			fmt.Sscanf(after, "%d", &start)
+			start++
+		}
+
+		end := min(start+settings.DiscordMaxReactions, len(users))
+		return users[start:end], nil
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(all) != 250 || requests != 3 {
+		t.Errorf("expected 250 users in 3 requests but got %v in %v", len(all), requests)
+	}
+}
+
+func TestGame_MergeEntrants(t *testing.T) {
+	g := testGame(GameConfig{})
+
+	emoji := settings.GetEmoji(settings.EmojiParticipant).Name
+	g.RegisterUser("123", emoji, NewParticipant(&discordgo.Member{User: &discordgo.User{ID: "seen", Username: "seen"}}))
+	g.RegisterUser("123", emoji, NewParticipant(&discordgo.Member{User: &discordgo.User{ID: "unreacted", Username: "unreacted"}}))
+
+	g.mergeEntrants([]*discordgo.User{
+		{ID: "seen", Username: "seen"},
+		{ID: "missed", Username: "missed"},
+		{ID: "bot", Username: "bot", Bot: true},
+	}, func(u *discordgo.User) *discordgo.Member {
+		return &discordgo.Member{User: u, Nick: "nick"}
+	})
+
+	var ids []string
+	for _, p := range g.participants {
+		ids = append(ids, p.User.ID)
+	}
+
+	if strings.Join(ids, ",") != "seen,unreacted,missed" {
+		t.Errorf("expected the missed tribute to be added after the registered ones but got %v", ids)
+	}
+
+	if p := g.participantMap["missed"]; p == nil || p.DisplayName() != "nick" {
+		t.Errorf("expected the missed tribute to be entered as a guild member but got %+v", p)
+	}
+}
+
+func TestGame_RerollUser(t *testing.T) {
+	jp, members := testSetupGameRun(t, 20, 1)
+	g := testGame(GameConfig{
+		PhraseGenerator: jp,
+		VictorCount:     1,
+	})
+
+	testEnter(g, members)
+
+	victors := g.run(context.Background())
+	if len(g.Awards()) != 0 {
+		t.Fatalf("expected no awards without prizes or claims but got %+v", g.Awards())
+	}
+
+	if _, _, err := g.RerollUser("nobody"); err != ErrNotAWinner {
+		t.Errorf("expected ErrNotAWinner when rerolling a non-winner but got %v", err)
+	}
+
+	revoked, next, err := g.RerollUser(victors[0].User.ID)
+	if err != nil {
+		t.Fatalf("expected the victor to be rerolled but got %v", err)
+	}
+
+	if revoked.Status != ClaimRevoked || revoked.Participant.User.ID != victors[0].User.ID {
+		t.Errorf("expected the victor's award to be revoked but got %+v", revoked)
+	}
+
+	if next.Participant == nil || next.Participant.User.ID == victors[0].User.ID || next.Place != 2 {
+		t.Errorf("expected the 2nd place tribute to take over but got %+v", next)
+	}
+
+	if _, _, err := g.RerollUser(victors[0].User.ID); err != ErrNotAWinner {
+		t.Errorf("expected ErrNotAWinner when rerolling a revoked victor again but got %v", err)
+	}
+
+	rec := g.Record()
+	if current := rec.CurrentVictors(); len(current) != 1 || current[0].UserID != next.Participant.User.ID {
+		t.Errorf("expected the record to credit the replacement but got %v", current)
+	}
+
+	// A restart loses the game, but the record has everything a reroll needs.
+	restored := restoreGame(rec, GameConfig{
+		Channel: &discordgo.Channel{ID: "123"},
+		Guild:   &discordgo.Guild{ID: "123"},
+		Sender:  &BufferSender{},
+	})
+
+	if _, _, err := restored.RerollUser(victors[0].User.ID); err != ErrNotAWinner {
+		t.Errorf("expected the restored game to remember the disqualification but got %v", err)
+	}
+
+	_, third, err := restored.RerollUser(next.Participant.User.ID)
+	if err != nil || third.Participant == nil || third.Place != 3 {
+		t.Fatalf("expected the 3rd place tribute to take over in the restored game but got %+v, %v", third, err)
+	}
+
+	if current := restored.Record().CurrentVictors(); len(current) != 1 || current[0].UserID != third.Participant.User.ID {
+		t.Errorf("expected the restored record to credit the new replacement but got %v", current)
+	}
+}
+
+func TestGame_RerollUser_Concurrent(t *testing.T) {
+	jp, members := testSetupGameRun(t, 20, 1)
+	g := testGame(GameConfig{
+		PhraseGenerator: jp,
+		VictorCount:     2,
+	})
+
+	testEnter(g, members)
+
+	victors := g.run(context.Background())
+
+	var wg sync.WaitGroup
+	for _, v := range victors {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			g.RerollUser(v.User.ID)
+		}()
+	}
+	wg.Wait()
+
+	slots := make(map[int]int)
+	for _, a := range g.Awards() {
+		slots[a.Slot]++
+	}
+
+	// Each slot has the revoked award and its replacement.
+	if len(slots) != 2 || slots[0] != 2 || slots[1] != 2 {
+		t.Errorf("expected both victor slots to be assigned once and rerolled once but got %v", slots)
+	}
+}
+
 type Fataler interface {
 	Helper()
 	Fatal(args ...any)
@@ -170,26 +757,7 @@ func BenchmarkGameDuration(b *testing.B) {
 			jp, users := testSetupGameRun(b, test.UserCount, test.Clone)
 
 			for i := 0; i < b.N; i++ {
-				testRunGame(
-					b,
-					GameConfig{
-						Channel:         &discordgo.Channel{ID: "123", Name: "123"},
-						Guild:           &discordgo.Guild{ID: "123", Name: "123"},
-						DayDelay:        1 * time.Nanosecond,
-						PhraseGenerator: jp,
-						Session:         &discordgo.Session{},
-						Sponsor:         "Sponsor",
-						Clone:           test.Clone,
-						StartedBy: NewParticipant(&discordgo.Member{
-							User: &discordgo.User{
-								ID:       "123",
-								Username: "Hello",
-							},
-						}),
-						VictorCount: 1,
-					},
-					users,
-				)
+				testRunGame(b, GameConfig{PhraseGenerator: jp, Clone: test.Clone, VictorCount: 1}, users)
 			}
 		})
 	}
@@ -197,7 +765,11 @@ func BenchmarkGameDuration(b *testing.B) {
 
 type BufferSender struct {
 	buffer      []string
+	dms         []string
+	dmUsers     []string // recipient of each DM
+	edits       []*discordgo.MessageEdit
 	SendLatency time.Duration
+	OnDM        func() // called before each DM is recorded
 	sync.Mutex
 }
 
@@ -213,10 +785,33 @@ func (b *BufferSender) SendEmbed(str string) (*discordgo.Message, error) {
 	return b.send(str)
 }
 
+func (b *BufferSender) SendComplex(msg *discordgo.MessageSend) (*discordgo.Message, error) {
+	return b.send(msg.Content)
+}
+
 func (b *BufferSender) SendDM(user *discordgo.User, msg string) error {
+	if b.OnDM != nil {
+		b.OnDM()
+	}
+
+	b.Lock()
+	defer b.Unlock()
+	b.dms = append(b.dms, msg)
+	b.dmUsers = append(b.dmUsers, user.ID)
 	return nil
 }
 
+func (b *BufferSender) SendDMComplex(user *discordgo.User, msg *discordgo.MessageSend) (*discordgo.Message, error) {
+	return nil, nil
+}
+
+func (b *BufferSender) EditComplex(edit *discordgo.MessageEdit) (*discordgo.Message, error) {
+	b.Lock()
+	defer b.Unlock()
+	b.edits = append(b.edits, edit)
+	return nil, nil
+}
+
 func (b *BufferSender) send(str string) (*discordgo.Message, error) {
 	b.Lock()
 	defer b.Unlock()
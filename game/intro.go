@@ -0,0 +1,157 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/deadloct/bitheroes-hg-bot/settings"
+	log "github.com/sirupsen/logrus"
+)
+
+// EntriesClose returns when the game starts. It's zero until the intro is sent.
+func (g *Game) EntriesClose() time.Time {
+	g.Lock()
+	defer g.Unlock()
+
+	return g.entriesClose
+}
+
+// awaitStart keeps the intro up to date and sends reminders until entries
+// close, then runs the game.
+func (g *Game) awaitStart(ctx context.Context, jokeCh chan struct{}) {
+	start := time.NewTimer(time.Until(g.EntriesClose()))
+	defer start.Stop()
+
+	updates := time.NewTicker(settings.IntroUpdateInterval)
+	defer updates.Stop()
+
+	reminder, offset := g.nextReminder(time.Now())
+	defer func() { reminder.Stop() }()
+
+	shown := -1
+	status := ""
+	for {
+		select {
+		case <-ctx.Done():
+			jokeCh <- struct{}{}
+			g.logMessage(log.InfoLevel, "context done, cancelling game")
+			g.Lock()
+			g.state = Cancelled
+			g.Unlock()
+			return
+
+		case <-start.C:
+			g.logMessage(log.InfoLevel, "delay timer ended, sending msg to joke ch to end the jester")
+			g.Lock()
+			g.closing = true
+			g.Unlock()
+
+			jokeCh <- struct{}{}
+			g.closeEntries()
+			g.reconcileEntrants()
+			g.updateIntro(fmt.Sprintf("**Entries closed — %v**", TributeCount(g.entrantCount())))
+			g.logMessage(log.InfoLevel, "starting game...")
+			g.run(ctx)
+			return
+
+		case <-updates.C:
+			if count := g.entrantCount(); count != shown {
+				shown = count
+				status = fmt.Sprintf("**%v** so far.", TributeCount(count))
+				g.updateIntro(status)
+			}
+
+		case <-g.rescheduled:
+			g.logMessage(log.InfoLevel, "entries close in %v, rearming timers", time.Until(g.EntriesClose()))
+			start.Reset(time.Until(g.EntriesClose()))
+			reminder.Stop()
+			reminder, offset = g.nextReminder(time.Now())
+			g.announceSchedule()
+			g.updateIntro(status)
+
+		case <-reminder.C:
+			g.sendReminder(offset)
+			reminder, offset = g.nextReminder(time.Now())
+		}
+	}
+}
+
+// nextReminder returns a timer for the next reminder due after now and how
+// long before entries close it is. The timer never fires when none is left.
+func (g *Game) nextReminder(now time.Time) (*time.Timer, time.Duration) {
+	closes := g.EntriesClose()
+	offsets := slices.Clone(g.Reminders)
+	slices.Sort(offsets)
+	slices.Reverse(offsets)
+
+	for _, offset := range offsets {
+		if at := closes.Add(-offset); offset > 0 && at.After(now) {
+			return time.NewTimer(at.Sub(now)), offset
+		}
+	}
+
+	t := time.NewTimer(time.Hour)
+	t.Stop()
+	return t, 0
+}
+
+func (g *Game) sendReminder(offset time.Duration) {
+	how := fmt.Sprintf("React to the intro with %v to enter.", g.emoji(settings.EmojiParticipant).EmojiCode())
+	if g.EntryMode == EntryButtons {
+		how = "Press **Enter** on the intro to join them."
+	}
+
+	msg := fmt.Sprintf("Entries close <t:%v:R>! %v entered so far. %v", g.EntriesClose().Unix(), TributeCount(g.entrantCount()), how)
+	if g.NotifyRole != "" {
+		msg = fmt.Sprintf("<@&%v> %v", g.NotifyRole, msg)
+	}
+
+	g.logMessage(log.InfoLevel, "sending the %v reminder", offset)
+	if _, err := g.Sender.SendQuoted(msg); err != nil {
+		g.logMessage(log.ErrorLevel, "unable to send the %v reminder: %v", offset, err)
+	}
+}
+
+// updateIntro redraws the intro with status below the rules.
+func (g *Game) updateIntro(status string) {
+	g.Lock()
+	msg := g.introMessage
+	vals := g.introValues
+	vals.Deadline = g.entriesClose.Unix()
+	vals.Status = status
+	g.Unlock()
+
+	if msg == nil {
+		return
+	}
+
+	intro, err := g.getIntro(vals)
+	if err != nil {
+		g.logMessage(log.ErrorLevel, "unable to render the intro: %v", err)
+		return
+	}
+
+	embeds := []*discordgo.MessageEmbed{{Description: intro}}
+	if _, err := g.Sender.EditComplex(&discordgo.MessageEdit{ID: msg.ID, Channel: msg.ChannelID, Embeds: &embeds}); err != nil {
+		g.logMessage(log.ErrorLevel, "unable to update the intro: %v", err)
+	}
+}
+
+func (g *Game) entrantCount() int {
+	g.Lock()
+	defer g.Unlock()
+
+	return len(g.participants)
+}
+
+// TributeCount formats n like "1 tribute" or "5 tributes".
+func TributeCount(n int) string {
+	if n == 1 {
+		return "1 tribute"
+	}
+
+	return fmt.Sprintf("%v tributes", n)
+}
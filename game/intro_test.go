@@ -0,0 +1,65 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/deadloct/bitheroes-hg-bot/settings"
+)
+
+func TestGame_NextReminder(t *testing.T) {
+	now := time.Now()
+	g := NewGame(GameConfig{Reminders: []time.Duration{5 * time.Minute, 15 * time.Minute, time.Minute}})
+	g.entriesClose = now.Add(10 * time.Minute)
+
+	timer, offset := g.nextReminder(now)
+	timer.Stop()
+	if offset != 5*time.Minute {
+		t.Errorf("expected the 5 minute reminder first since 15 minutes is already past but got %v", offset)
+	}
+
+	timer, offset = g.nextReminder(now.Add(5 * time.Minute))
+	timer.Stop()
+	if offset != time.Minute {
+		t.Errorf("expected the 1 minute reminder next but got %v", offset)
+	}
+
+	timer, offset = g.nextReminder(now.Add(9*time.Minute + 30*time.Second))
+	if offset != 0 || timer.Stop() {
+		t.Errorf("expected no reminders left but got %v", offset)
+	}
+}
+
+func TestGame_UpdateIntro(t *testing.T) {
+	settings.ImportData()
+	sender := &BufferSender{}
+	g := testGame(GameConfig{
+		Delay:     10 * time.Minute,
+		EntryMode: EntryButtons,
+		Sender:    sender,
+	})
+	g.introMessage = &discordgo.Message{ID: "intro", ChannelID: "123"}
+	g.entriesClose = time.Unix(1700000000, 0)
+	g.introValues.VictorCount = 1
+	g.introValues.EntryButtons = true
+
+	for i := 0; i < 3; i++ {
+		g.participants = append(g.participants, NewParticipant(&discordgo.Member{User: &discordgo.User{ID: fmt.Sprint(i)}}))
+	}
+
+	g.updateIntro(fmt.Sprintf("**Entries closed — %v**", TributeCount(g.entrantCount())))
+
+	if len(sender.edits) != 1 || sender.edits[0].ID != "intro" || sender.edits[0].Embeds == nil {
+		t.Fatalf("expected the intro embed to be edited but got %v", sender.edits)
+	}
+
+	intro := (*sender.edits[0].Embeds)[0].Description
+	for _, want := range []string{"<t:1700000000:R>", "Entries closed — 3 tributes"} {
+		if !strings.Contains(intro, want) {
+			t.Errorf("expected the intro to contain %q but got %v", want, intro)
+		}
+	}
+}
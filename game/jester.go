@@ -16,10 +16,11 @@ type Jester struct {
 	generator JokeGenerator
 	sender    Sender
 	session   *discordgo.Session
+	host      settings.EmojiInfo
 }
 
-func NewJester(jg JokeGenerator, s Sender, sess *discordgo.Session) *Jester {
-	return &Jester{generator: jg, sender: s, session: sess}
+func NewJester(jg JokeGenerator, s Sender, sess *discordgo.Session, host settings.EmojiInfo) *Jester {
+	return &Jester{generator: jg, sender: s, session: sess, host: host}
 }
 
 func (j *Jester) StartRandomJokes(ctx context.Context, stop chan struct{}) {
@@ -63,9 +64,8 @@ func (j *Jester) StartRandomJokes(ctx context.Context, stop chan struct{}) {
 }
 
 func (j *Jester) sendJoke(msg *discordgo.Message) (*discordgo.Message, error) {
-	caesarEmoji := settings.GetEmoji(settings.EmojiCaesar)
 	intro := fmt.Sprintf("%v  %v",
-		caesarEmoji.EmojiCode(),
+		j.host.EmojiCode(),
 		"Greetings, esteemed guests and citizens of the Capitol! I am Caesar Flickerman, the host of this year's Hunger Games! What a time to be alive! I stand before you today to bring some much-needed levity and humor to this esteemed gathering. I understand that some of you may be feeling impatient, but fear not! I am here to entertain you with the finest collection of fatherly quips this side of the Districts.",
 	)
 
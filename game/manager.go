@@ -4,11 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/deadloct/bitheroes-hg-bot/lib"
 	"github.com/deadloct/bitheroes-hg-bot/settings"
+	"github.com/deadloct/bitheroes-hg-bot/storage"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -20,19 +23,37 @@ type RunningGame struct {
 type GameStartConfig struct {
 	Channel         *discordgo.Channel
 	Guild           *discordgo.Guild
+	ClaimDeadline   time.Duration
+	DayDelay        time.Duration // 0 uses settings.DefaultDayDelay
 	Delay           time.Duration
 	Clone           int
+	Emojis          map[settings.EmojiKey]settings.EmojiInfo
+	Eligibility     Eligibility
+	EntryMode       EntryMode
 	JokeGenerator   JokeGenerator
+	Locale          string
 	MinimumTier     int
 	Notify          *discordgo.User
+	NotifyRole      string
+	Pacing          PacingStrategy
 	PhraseGenerator PhraseGenerator
+	Prizes          []string
+	ProvablyFair    bool
+	Randomizer      lib.Randomizer
+	Reminders       []time.Duration
+	Seed            uint64
 	Sponsor         string
 	StartedBy       *Participant
+	Store           storage.Store
+	TargetDuration  time.Duration
 	VictorCount     int
+	Weighers        []Weigher
+	Winners         *WinnerPolicy
 }
 
 type Manager struct {
 	games   map[string]*RunningGame // maps channel ID to games; one allowed per channel at a time
+	archive map[string]*Game        // maps game ID to games that still accept interactions, like prize claims and rerolls
 	session *discordgo.Session
 	sync.Mutex
 }
@@ -46,6 +67,7 @@ func ManagerInstance(session *discordgo.Session) *Manager {
 	managerSingletonOnce.Do(func() {
 		managerSingleton = &Manager{
 			games:   make(map[string]*RunningGame),
+			archive: make(map[string]*Game),
 			session: session,
 		}
 	})
@@ -69,22 +91,44 @@ func (m *Manager) StartGame(cfg GameStartConfig) error {
 	sender.SendQuoted("Starting a Hunger Games event in this channel.")
 	m.EndGame(cfg.Channel.ID)
 
-	log.Infof("%v started a game channel:%v server:%v config:%#v", cfg.StartedBy.DisplayFullName(), cfg.Channel.Name, cfg.Guild.Name, cfg)
+	// The seed stays out of the logs until the game reveals it.
+	log.Infof(
+		"%v started a game channel:%v server:%v delay:%v day delay:%v target:%v victors:%v clone:%v prizes:%v provably fair:%v",
+		cfg.StartedBy.DisplayFullName(), cfg.Channel.Name, cfg.Guild.Name, cfg.Delay, cfg.DayDelay, cfg.TargetDuration,
+		cfg.VictorCount, cfg.Clone, len(cfg.Prizes), cfg.ProvablyFair,
+	)
 
 	g := NewGame(GameConfig{
 		Delay:           cfg.Delay,
 		Guild:           cfg.Guild,
 		Channel:         cfg.Channel,
+		ClaimDeadline:   cfg.ClaimDeadline,
+		DayDelay:        cfg.DayDelay,
 		Clone:           cfg.Clone,
+		Emojis:          cfg.Emojis,
+		Eligibility:     cfg.Eligibility,
+		EntryMode:       cfg.EntryMode,
 		JokeGenerator:   cfg.JokeGenerator,
+		Locale:          cfg.Locale,
 		MinimumTier:     cfg.MinimumTier,
 		Notify:          cfg.Notify,
+		NotifyRole:      cfg.NotifyRole,
 		PhraseGenerator: cfg.PhraseGenerator,
+		Prizes:          cfg.Prizes,
+		ProvablyFair:    cfg.ProvablyFair,
+		Randomizer:      cfg.Randomizer,
+		Reminders:       cfg.Reminders,
+		Seed:            cfg.Seed,
 		Sender:          sender,
 		Session:         m.session,
 		Sponsor:         cfg.Sponsor,
 		StartedBy:       cfg.StartedBy,
+		Store:           cfg.Store,
+		TargetDuration:  cfg.TargetDuration,
 		VictorCount:     cfg.VictorCount,
+		Pacing:          cfg.Pacing,
+		Weighers:        cfg.Weighers,
+		Winners:         cfg.Winners,
 	})
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -102,21 +146,186 @@ func (m *Manager) StartGame(cfg GameStartConfig) error {
 		Game:   g,
 		Cancel: cancel,
 	}
+	m.pruneArchive()
+	m.archive[g.ID()] = g
 	m.Unlock()
 
 	return nil
 }
 
-func (m *Manager) ReactionHandler(session *discordgo.Session, mra *discordgo.MessageReactionAdd) {
+// InteractionHandler handles message components, like the entry and prize claim buttons.
+func (m *Manager) InteractionHandler(session *discordgo.Session, ic *discordgo.InteractionCreate) {
+	if ic.Type != discordgo.InteractionMessageComponent {
+		return
+	}
+
+	customID := ic.MessageComponentData().CustomID
+	switch {
+	case customID == EnterButtonID || customID == WithdrawButtonID:
+		m.handleEntry(session, ic, customID == EnterButtonID)
+	case strings.HasPrefix(customID, ClaimButtonPrefix+":"):
+		m.handleClaim(session, ic, customID)
+	}
+}
+
+func (m *Manager) handleEntry(session *discordgo.Session, ic *discordgo.InteractionCreate, enter bool) {
+	if ic.Member == nil {
+		return
+	}
+
+	g, ok := m.game(ic.ChannelID)
+
+	var count int
+	err := ErrEntriesClosed
+	if ok {
+		if enter {
+			count, err = g.Enter(ic.Message.ID, NewParticipant(ic.Member))
+		} else {
+			count, err = g.Withdraw(ic.Message.ID, ic.Member.User.ID)
+		}
+	}
+
+	var content string
+	switch {
+	case err != nil:
+		log.Infof("user %v could not enter=%v in channel %v: %v", ic.Member.User.ID, enter, ic.ChannelID, err)
+		content = fmt.Sprintf("Sorry, %v.", err)
+	case enter:
+		content = fmt.Sprintf("You're in! %v so far.", TributeCount(count))
+		if g.Winners.Handicapped(ic.Member.User.ID) {
+			content += " " + g.Winners.HandicapNotice()
+		}
+	default:
+		content = fmt.Sprintf("You've withdrawn. %v remaining.", TributeCount(count))
+	}
+
+	err = session.InteractionRespond(ic.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{Content: content, Flags: discordgo.MessageFlagsEphemeral},
+	})
+	if err != nil {
+		log.Errorf("error responding to entry interaction: %v", err)
+	}
+}
+
+func (m *Manager) handleClaim(session *discordgo.Session, ic *discordgo.InteractionCreate, customID string) {
+	user := ic.User
+	if ic.Member != nil {
+		user = ic.Member.User
+	}
+
+	gameID, slot, err := ParseClaimButtonID(customID)
+	if err != nil {
+		log.Errorf("could not parse claim button %v: %v", customID, err)
+		return
+	}
+
+	m.Lock()
+	g, ok := m.archive[gameID]
+	m.Unlock()
+
+	var award Award
+	if !ok {
+		err = ErrClaimNotFound
+	} else {
+		award, err = g.Claim(user.ID, slot)
+	}
+
+	if err != nil {
+		log.Infof("user %v could not claim slot %v of game %v: %v", user.ID, slot, gameID, err)
+		err = session.InteractionRespond(ic.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: fmt.Sprintf("Sorry, %v.", err),
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+	} else {
+		err = session.InteractionRespond(ic.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: &discordgo.InteractionResponseData{
+				Content:    fmt.Sprintf("You claimed %v! The sponsor has been told, so keep an eye on your DMs.", g.prizeLabel(award)),
+				Components: []discordgo.MessageComponent{},
+			},
+		})
+	}
+
+	if err != nil {
+		log.Errorf("error responding to claim interaction: %v", err)
+	}
+}
+
+// LastFinishedGame returns the most recently finished game in a channel, or nil.
+func (m *Manager) LastFinishedGame(channelID string) *Game {
 	m.Lock()
 	defer m.Unlock()
 
-	rg, ok := m.games[mra.ChannelID]
+	return m.lastFinished()[channelID]
+}
+
+// lastFinished maps channel IDs to their most recently finished game. It must be
+// called with the lock held.
+func (m *Manager) lastFinished() map[string]*Game {
+	last := make(map[string]*Game)
+	for _, g := range m.archive {
+		if !g.IsFinished() {
+			continue
+		}
+
+		if prev, ok := last[g.Channel.ID]; !ok || g.FinishedAt().After(prev.FinishedAt()) {
+			last[g.Channel.ID] = g
+		}
+	}
+
+	return last
+}
+
+// pruneArchive forgets games that can no longer be interacted with. The last
+// finished game in each channel is kept for /hg-reroll. It must be called with
+// the lock held.
+func (m *Manager) pruneArchive() {
+	last := m.lastFinished()
+	for id, g := range m.archive {
+		if g.IsRunning() || g.HasPendingClaims() || last[g.Channel.ID] == g {
+			continue
+		}
+
+		delete(m.archive, id)
+	}
+}
+
+func (m *Manager) ReactionHandler(session *discordgo.Session, mra *discordgo.MessageReactionAdd) {
+	g, ok := m.game(mra.ChannelID)
+	if !ok {
+		return
+	}
+
+	g.RegisterUser(mra.MessageID, mra.Emoji.Name, NewParticipant(mra.Member))
+}
+
+// ReactionRemoveHandler withdraws users who take back their entry reaction
+// before the game starts.
+func (m *Manager) ReactionRemoveHandler(session *discordgo.Session, mrr *discordgo.MessageReactionRemove) {
+	g, ok := m.game(mrr.ChannelID)
 	if !ok {
 		return
 	}
 
-	rg.Game.RegisterUser(mra.MessageID, mra.Emoji.Name, NewParticipant(mra.Member))
+	g.WithdrawUser(mrr.MessageID, mrr.Emoji.Name, mrr.UserID)
+}
+
+// game returns the game in channel. The lock is only held for the lookup, since
+// calls into a game can send DMs and would hold up every other channel.
+func (m *Manager) game(channel string) (*Game, bool) {
+	m.Lock()
+	defer m.Unlock()
+
+	rg, ok := m.games[channel]
+	if !ok {
+		return nil, false
+	}
+
+	return rg.Game, true
 }
 
 func (m *Manager) EndGame(channel string) {
@@ -130,6 +339,18 @@ func (m *Manager) EndGame(channel string) {
 	}
 }
 
+// ActiveGame returns the game waiting for entrants or running in a channel, or nil.
+func (m *Manager) ActiveGame(channel string) *Game {
+	m.Lock()
+	defer m.Unlock()
+
+	if rg, exists := m.games[channel]; exists && rg.Game.IsRunning() {
+		return rg.Game
+	}
+
+	return nil
+}
+
 func (m *Manager) CanStart(channel string) bool {
 	m.Lock()
 	defer m.Unlock()
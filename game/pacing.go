@@ -0,0 +1,145 @@
+package game
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	PacingClassic   = "classic"
+	PacingSlowBurn  = "slow-burn"
+	PacingBloodbath = "bloodbath"
+	PacingFinishIn  = "finish-in" // followed by the number of days, like finish-in-5
+)
+
+// PacingStrategy decides how many tributes may die each day, which sets how
+// long a game runs.
+type PacingStrategy interface {
+	// KillRange returns the smallest and one more than the largest number of
+	// tributes that may die on day, 0-based, with alive tributes left. runDay
+	// forces at least one kill after settings.MaxQuietDays quiet days and never
+	// kills more than alive-victors.
+	KillRange(day, alive, victors int) (low, high int)
+
+	// Name identifies the strategy in history records and hg-verify.
+	Name() string
+
+	// Describe explains the strategy in the intro.
+	Describe() string
+}
+
+// ClassicPacing kills up to half the field each day, and half to three
+// quarters of it on the first day.
+type ClassicPacing struct{}
+
+func (ClassicPacing) KillRange(day, alive, victors int) (int, int) {
+	var low int
+	high := 1 + min(alive/2, alive-victors)
+
+	// 1/2 - 3/4 on the first day, it's always a slaughter!
+	if day == 0 && alive > 5 {
+		low = high / 2
+		high = 1 + min(high*3/4, alive-victors)
+	}
+
+	return low, high
+}
+
+func (ClassicPacing) Name() string { return PacingClassic }
+
+func (ClassicPacing) Describe() string {
+	return "The arena is as merciless as ever."
+}
+
+// SlowBurnPacing kills at most a quarter of the field each day.
+type SlowBurnPacing struct{}
+
+func (SlowBurnPacing) KillRange(day, alive, victors int) (int, int) {
+	return 0, 1 + min(max(1, alive/4), alive-victors)
+}
+
+func (SlowBurnPacing) Name() string { return PacingSlowBurn }
+
+func (SlowBurnPacing) Describe() string {
+	return "This year's Games are a slow burn, so expect a long fight."
+}
+
+// BloodbathPacing kills a quarter to three quarters of the field every day.
+type BloodbathPacing struct{}
+
+func (BloodbathPacing) KillRange(day, alive, victors int) (int, int) {
+	high := min(max(1, alive*3/4), alive-victors)
+	return min(max(1, alive/4), high), high + 1
+}
+
+func (BloodbathPacing) Name() string { return PacingBloodbath }
+
+func (BloodbathPacing) Describe() string {
+	return "This year's Games are a bloodbath. Few will see a second day."
+}
+
+// FinishInPacing spreads the deaths so the game ends after at most Days days.
+type FinishInPacing struct {
+	Days int
+}
+
+func (p FinishInPacing) KillRange(day, alive, victors int) (int, int) {
+	need := alive - victors
+	left := p.Days - day
+	if left <= 1 {
+		return need, need + 1
+	}
+
+	// Killing at least need/left a day always finishes in time, and up to
+	// twice that keeps the days from all looking alike.
+	low := (need + left - 1) / left
+	return low, min(2*low, need) + 1
+}
+
+func (p FinishInPacing) Name() string { return fmt.Sprintf("%v-%v", PacingFinishIn, p.Days) }
+
+func (p FinishInPacing) Describe() string {
+	return fmt.Sprintf("The Gamemakers will crown the victors within %v.", pluralize(p.Days, "day"))
+}
+
+// ParsePacing is the inverse of PacingStrategy.Name. An empty name is classic.
+func ParsePacing(name string) (PacingStrategy, error) {
+	switch name {
+	case "", PacingClassic:
+		return ClassicPacing{}, nil
+	case PacingSlowBurn:
+		return SlowBurnPacing{}, nil
+	case PacingBloodbath:
+		return BloodbathPacing{}, nil
+	}
+
+	if days, ok := strings.CutPrefix(name, PacingFinishIn+"-"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("pacing %q needs a positive number of days", name)
+		}
+
+		return FinishInPacing{Days: n}, nil
+	}
+
+	return nil, fmt.Errorf("unknown pacing %q", name)
+}
+
+func (g *Game) pacing() PacingStrategy {
+	if g.Pacing == nil {
+		return ClassicPacing{}
+	}
+
+	return g.Pacing
+}
+
+// pacingRule describes the pacing for the intro, which says nothing about the
+// classic pacing everybody knows.
+func (g *Game) pacingRule() string {
+	if g.pacing().Name() == PacingClassic {
+		return ""
+	}
+
+	return g.pacing().Describe()
+}
@@ -0,0 +1,89 @@
+package game
+
+import (
+	"context"
+	"testing"
+
+	"github.com/deadloct/bitheroes-hg-bot/lib"
+)
+
+func TestClassicPacing_KillRange(t *testing.T) {
+	tests := []struct {
+		Day, Alive, Victors int
+		Low, High           int
+	}{
+		{Day: 0, Alive: 100, Victors: 1, Low: 25, High: 39},
+		{Day: 0, Alive: 5, Victors: 1, Low: 0, High: 3},
+		{Day: 1, Alive: 40, Victors: 1, Low: 0, High: 21},
+		{Day: 3, Alive: 3, Victors: 2, Low: 0, High: 2},
+	}
+
+	for _, test := range tests {
+		low, high := ClassicPacing{}.KillRange(test.Day, test.Alive, test.Victors)
+		if low != test.Low || high != test.High {
+			t.Errorf("day %v with %v alive: expected [%v, %v) but got [%v, %v)", test.Day, test.Alive, test.Low, test.High, low, high)
+		}
+	}
+}
+
+func TestParsePacing(t *testing.T) {
+	for _, p := range []PacingStrategy{ClassicPacing{}, SlowBurnPacing{}, BloodbathPacing{}, FinishInPacing{Days: 7}} {
+		parsed, err := ParsePacing(p.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if parsed != p {
+			t.Errorf("expected %v to parse to %#v but got %#v", p.Name(), p, parsed)
+		}
+	}
+
+	for _, name := range []string{"fast", "finish-in-0", "finish-in-x"} {
+		if _, err := ParsePacing(name); err == nil {
+			t.Errorf("expected %q to be rejected", name)
+		}
+	}
+}
+
+func TestGame_Run_Pacing(t *testing.T) {
+	jp, members := testSetupGameRun(t, 50, 1)
+
+	tests := []struct {
+		Pacing  PacingStrategy
+		MaxDays int // 0 to only check that the game finishes
+	}{
+		{Pacing: SlowBurnPacing{}},
+		{Pacing: BloodbathPacing{}},
+		{Pacing: FinishInPacing{Days: 1}, MaxDays: 1},
+		{Pacing: FinishInPacing{Days: 4}, MaxDays: 4},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Pacing.Name(), func(t *testing.T) {
+			g := testGame(GameConfig{
+				Pacing:          test.Pacing,
+				PhraseGenerator: jp,
+				Randomizer:      lib.NewSeededRandomizer(3),
+				VictorCount:     2,
+			})
+
+			for _, m := range members {
+				g.participantMap[m.User.ID] = NewParticipant(m)
+				g.participants = append(g.participants, g.participantMap[m.User.ID])
+			}
+
+			if victors := g.run(context.Background()); len(victors) != 2 {
+				t.Fatalf("expected 2 victors but got %v", len(victors))
+			}
+
+			var days int
+			for _, e := range g.eliminations {
+				days = max(days, e.Day+1)
+			}
+
+			if test.MaxDays > 0 && days > test.MaxDays {
+				t.Errorf("expected the game to end within %v days but it took %v", test.MaxDays, days)
+			}
+		})
+	}
+}
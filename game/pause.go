@@ -0,0 +1,98 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	ErrNotInArena    = errors.New("the game isn't in the arena")
+	ErrAlreadyPaused = errors.New("the arena is already paused")
+	ErrNotPaused     = errors.New("the arena isn't paused")
+	ErrNoDaysLeft    = errors.New("the final day has already begun")
+)
+
+// Pause holds the game before its next day until Resume is called. The day
+// being simulated, if any, still finishes, and when that day ends the game the
+// pause is dropped and the channel is told so.
+func (g *Game) Pause() error {
+	g.Lock()
+	if g.state != Started {
+		g.Unlock()
+		return ErrNotInArena
+	}
+
+	if len(g.participants) <= g.VictorCount {
+		g.Unlock()
+		return ErrNoDaysLeft
+	}
+
+	if g.resumed != nil {
+		g.Unlock()
+		return ErrAlreadyPaused
+	}
+
+	g.resumed = make(chan struct{})
+	g.pausedAt = time.Now()
+	g.Unlock()
+
+	g.logMessage(log.InfoLevel, "pausing the arena")
+	if _, err := g.Sender.SendQuoted("⏸️ The Gamemakers have paused the arena. The tributes catch their breath until the games resume."); err != nil {
+		g.logMessage(log.ErrorLevel, "unable to announce the pause: %v", err)
+	}
+
+	return nil
+}
+
+// Resume lets a paused game carry on with its next day.
+func (g *Game) Resume() error {
+	g.Lock()
+	if g.resumed == nil {
+		g.Unlock()
+		return ErrNotPaused
+	}
+
+	// The pause doesn't count toward a target duration.
+	if !g.deadline.IsZero() {
+		g.deadline = g.deadline.Add(time.Since(g.pausedAt))
+	}
+
+	close(g.resumed)
+	g.resumed = nil
+	g.Unlock()
+
+	g.logMessage(log.InfoLevel, "resuming the arena")
+	if _, err := g.Sender.SendQuoted("▶️ The Gamemakers have resumed the arena. Let the games continue!"); err != nil {
+		g.logMessage(log.ErrorLevel, "unable to announce the resume: %v", err)
+	}
+
+	return nil
+}
+
+func (g *Game) IsPaused() bool {
+	g.Lock()
+	defer g.Unlock()
+
+	return g.resumed != nil
+}
+
+// waitWhilePaused blocks between days while the game is paused, returning
+// early when ctx is done.
+func (g *Game) waitWhilePaused(ctx context.Context) {
+	g.Lock()
+	resumed := g.resumed
+	g.Unlock()
+
+	if resumed == nil {
+		return
+	}
+
+	g.logMessage(log.InfoLevel, "waiting for the arena to resume")
+	select {
+	case <-resumed:
+	case <-ctx.Done():
+	}
+}
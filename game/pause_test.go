@@ -0,0 +1,96 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGame_PauseResume(t *testing.T) {
+	jp, members := testSetupGameRun(t, 10, 1)
+	sender := &BufferSender{}
+	g := testGame(GameConfig{
+		PhraseGenerator: jp,
+		Sender:          sender,
+		VictorCount:     1,
+	})
+
+	for _, m := range members {
+		if _, err := g.Enter("123", NewParticipant(m)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := g.Pause(); !errors.Is(err, ErrNotInArena) {
+		t.Errorf("expected a waiting game not to be paused but got %v", err)
+	}
+
+	g.state = Started
+	if err := g.Pause(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := g.Pause(); !errors.Is(err, ErrAlreadyPaused) {
+		t.Errorf("expected a second pause to fail but got %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		g.run(context.Background())
+		close(done)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	if s := g.Status(); !s.Paused || s.Day != 0 || s.Alive != 10 {
+		t.Errorf("expected the game to wait before day 1 with every tribute alive but got %+v", s)
+	}
+
+	if err := g.Resume(); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("expected the game to finish after resuming")
+	}
+
+	if s := g.Status(); s.State != Finished || s.Paused || s.Alive != 1 {
+		t.Errorf("expected a finished game with 1 victor but got %+v", s)
+	}
+
+	if err := g.Resume(); !errors.Is(err, ErrNotPaused) {
+		t.Errorf("expected resuming an unpaused game to fail but got %v", err)
+	}
+}
+
+func TestGame_PauseDuringLastDay(t *testing.T) {
+	jp, members := testSetupGameRun(t, 1, 1)
+	sender := &BufferSender{}
+	g := testGame(GameConfig{
+		PhraseGenerator: jp,
+		Sender:          sender,
+		VictorCount:     1,
+	})
+	g.participants = append(g.participants, NewParticipant(members[0]))
+
+	g.state = Started
+	if err := g.Pause(); !errors.Is(err, ErrNoDaysLeft) {
+		t.Errorf("expected a game without days left not to be paused but got %v", err)
+	}
+
+	// A pause accepted while the last day was simulated.
+	g.resumed = make(chan struct{})
+	g.run(context.Background())
+
+	if s := g.Status(); s.State != Finished || s.Paused {
+		t.Errorf("expected the game to finish without staying paused but got %+v", s)
+	}
+
+	if !slices.ContainsFunc(sender.buffer, func(msg string) bool { return strings.Contains(msg, "pause came too late") }) {
+		t.Errorf("expected the channel to be told the pause was dropped but got %v", sender.buffer)
+	}
+}
@@ -0,0 +1,138 @@
+package game
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/deadloct/bitheroes-hg-bot/lib"
+	log "github.com/sirupsen/logrus"
+)
+
+// Award is a prize slot and the tribute that won it. When a slot is rerolled
+// the old award is kept with its final status and a new one is appended.
+type Award struct {
+	Slot        int // index into GameConfig.Prizes
+	Prize       string
+	Place       int
+	Participant *Participant
+	Status      ClaimStatus
+	Deadline    time.Time // only set while claims are enabled
+}
+
+func (a Award) PrizeName() string {
+	return fmt.Sprintf("%v prize", lib.Ordinal(a.Slot+1))
+}
+
+// Awards is empty unless the game was started with prizes or a claim deadline,
+// or a victor was rerolled.
+func (g *Game) Awards() []Award {
+	g.Lock()
+	defer g.Unlock()
+
+	return append([]Award(nil), g.awards...)
+}
+
+// awardPrizes hands out prizes down the standings. Ties are broken with the
+// game's randomizer so that replays award the same prizes, and nobody receives
+// more than one prize even if several of their clones placed. Without prizes
+// but with claims enabled, every victor gets a slot for the sponsor's prize.
+func (g *Game) awardPrizes() error {
+	if len(g.Prizes) == 0 && g.ClaimDeadline == 0 {
+		return nil
+	}
+
+	g.Lock()
+	defer g.Unlock()
+
+	return g.assignAwards()
+}
+
+// assignAwards must be called with the lock held.
+func (g *Game) assignAwards() error {
+	ranking, err := g.ranking()
+	if err != nil {
+		return err
+	}
+
+	awarded := make(map[string]struct{})
+	var awards []Award
+	for _, r := range ranking {
+		if len(g.Prizes) > 0 && len(awards) == len(g.Prizes) {
+			break
+		}
+
+		if len(g.Prizes) == 0 && r.Place > 1 {
+			break
+		}
+
+		if _, ok := awarded[r.Participant.User.ID]; ok {
+			continue
+		}
+
+		prize := g.Sponsor
+		if len(g.Prizes) > 0 {
+			prize = g.Prizes[len(awards)]
+		}
+
+		awarded[r.Participant.User.ID] = struct{}{}
+		awards = append(awards, Award{
+			Slot:        len(awards),
+			Prize:       prize,
+			Place:       r.Place,
+			Participant: r.Participant,
+		})
+
+		g.logMessage(log.InfoLevel, "awarded %v to %v", prize, r.Participant.DisplayFullName())
+	}
+
+	g.ranked = ranking
+	g.awards = awards
+
+	return nil
+}
+
+// prizeLabel describes an award's prize for announcements and DMs.
+func (g *Game) prizeLabel(a Award) string {
+	if len(g.Prizes) == 0 {
+		return fmt.Sprintf("**%v**", a.Prize)
+	}
+
+	return fmt.Sprintf("%v: **%v**", a.PrizeName(), a.Prize)
+}
+
+func (g *Game) introPrizes() []string {
+	var prizes []string
+	for i, prize := range g.Prizes {
+		prizes = append(prizes, fmt.Sprintf("%v prize: **%v**", lib.Ordinal(i+1), prize))
+	}
+
+	return prizes
+}
+
+type ranked struct {
+	Place       int
+	Participant *Participant
+}
+
+// ranking flattens the standings into a strict order, shuffling each tie. It
+// must be called with the lock held.
+func (g *Game) ranking() ([]ranked, error) {
+	var result []ranked
+	for _, s := range g.standings() {
+		group := s.Participants
+		for i := len(group) - 1; i > 0; i-- {
+			j, err := g.Randomizer.GetRandomInt(0, i+1)
+			if err != nil {
+				return nil, err
+			}
+
+			group[i], group[j] = group[j], group[i]
+		}
+
+		for _, p := range group {
+			result = append(result, ranked{Place: s.Place, Participant: p})
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,125 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/deadloct/bitheroes-hg-bot/settings"
+	log "github.com/sirupsen/logrus"
+)
+
+// reconcileEntrants adds everybody who reacted to the intro but whose reaction
+// event never arrived, for example because the gateway reconnected during signup.
+func (g *Game) reconcileEntrants() {
+	if g.EntryMode == EntryButtons || g.Session == nil || g.introMessage == nil {
+		return
+	}
+
+	emoji := g.emoji(settings.EmojiParticipant)
+	users, err := fetchAllReactions(func(after string) ([]*discordgo.User, error) {
+		return g.Session.MessageReactions(g.introMessage.ChannelID, g.introMessage.ID,
+			fmt.Sprintf("%v:%v", emoji.Name, emoji.ID), settings.DiscordMaxReactions, "", after)
+	})
+	if err != nil {
+		g.logMessage(log.ErrorLevel, "unable to fetch intro reactions, keeping the registered entrants: %v", err)
+		return
+	}
+
+	g.mergeEntrants(users, func(u *discordgo.User) *discordgo.Member {
+		m, err := g.Session.GuildMember(g.Guild.ID, u.ID)
+		if err != nil {
+			g.logMessage(log.WarnLevel, "unable to fetch member %v, entering them by username: %v", u.ID, err)
+			return &discordgo.Member{GuildID: g.Guild.ID, User: u}
+		}
+
+		return m
+	})
+}
+
+// fetchAllReactions pages through a message's reactions, since Discord returns
+// at most DiscordMaxReactions users per request.
+func fetchAllReactions(fetch func(after string) ([]*discordgo.User, error)) ([]*discordgo.User, error) {
+	var all []*discordgo.User
+	var after string
+	for {
+		users, err := fetch(after)
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, users...)
+		if len(users) < settings.DiscordMaxReactions {
+			return all, nil
+		}
+
+		after = users[len(users)-1].ID
+	}
+}
+
+// mergeEntrants registers every user that reacted but isn't registered yet and
+// logs registered users whose reaction is missing. Those are kept, since they
+// entered through an event the bot did see.
+func (g *Game) mergeEntrants(users []*discordgo.User, member func(*discordgo.User) *discordgo.Member) {
+	reacted := make(map[string]struct{}, len(users))
+	var missing []*discordgo.User
+	for _, u := range users {
+		if u.Bot {
+			continue
+		}
+
+		reacted[u.ID] = struct{}{}
+
+		g.Lock()
+		_, ok := g.participantMap[u.ID]
+		g.Unlock()
+
+		if !ok {
+			missing = append(missing, u)
+		}
+	}
+
+	// Members are fetched before taking the lock since every fetch is a request.
+	var added []*Participant
+	for _, u := range missing {
+		added = append(added, NewParticipant(member(u)))
+	}
+
+	g.Lock()
+	defer g.Unlock()
+
+	if g.state != NotStarted {
+		return
+	}
+
+	var names []string
+	for _, p := range added {
+		if _, ok := g.participantMap[p.User.ID]; ok {
+			continue
+		}
+
+		if err := g.checkEntry(p); err != nil {
+			g.logMessage(log.InfoLevel, "not registering missed tribute %v: %v", p.DisplayFullName(), err)
+			continue
+		}
+
+		g.participantMap[p.User.ID] = p
+		g.participants = append(g.participants, p)
+		names = append(names, p.DisplayFullName())
+	}
+
+	if len(names) > 0 {
+		g.logMessage(log.WarnLevel, "registered %v tributes whose reaction events were missed: %v", len(names), strings.Join(names, ", "))
+	}
+
+	var unreacted []string
+	for _, p := range g.participants {
+		if _, ok := reacted[p.User.ID]; !ok {
+			unreacted = append(unreacted, p.DisplayFullName())
+		}
+	}
+
+	if len(unreacted) > 0 {
+		g.logMessage(log.WarnLevel, "%v registered tributes have no reaction on the intro: %v", len(unreacted), strings.Join(unreacted, ", "))
+	}
+}
@@ -0,0 +1,146 @@
+package game
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/deadloct/bitheroes-hg-bot/storage"
+	log "github.com/sirupsen/logrus"
+)
+
+type Elimination struct {
+	Day         int
+	Participant *Participant
+	Killer      *Participant // nil unless the phrase credits a killer
+	Phrase      string
+}
+
+// ID is the intro message ID, which is unique and easy to find in Discord.
+func (g *Game) ID() string {
+	if g.introMessage != nil && g.introMessage.ID != "" {
+		return g.introMessage.ID
+	}
+
+	return fmt.Sprintf("%d", g.createdAt.UnixNano())
+}
+
+func (g *Game) FinishedAt() time.Time {
+	g.Lock()
+	defer g.Unlock()
+
+	return g.finishedAt
+}
+
+func (g *Game) Record() *storage.GameRecord {
+	g.Lock()
+	defer g.Unlock()
+
+	rec := &storage.GameRecord{
+		ID:        g.ID(),
+		GuildID:   g.Guild.ID,
+		ChannelID: g.Channel.ID,
+		Sponsor:   g.Sponsor,
+		StartedBy: playerRecord(g.StartedBy),
+		Config: storage.GameConfigRecord{
+			ClaimDeadline: g.ClaimDeadline,
+			Clone:         g.Clone,
+			DayDelay:      g.DayDelay,
+			Delay:         g.Delay,
+			MinimumTier:   g.MinimumTier,
+			Pacing:        g.pacing().Name(),
+			Prizes:        g.Prizes,
+			ProvablyFair:  g.ProvablyFair,
+			Seed:          g.Seed,
+			Target:        g.TargetDuration,
+			VictorCount:   g.VictorCount,
+			Weights:       g.SurvivalWeights,
+			WeightRules:   g.weightRules(),
+		},
+		CreatedAt:  g.createdAt,
+		StartedAt:  g.startedAt,
+		FinishedAt: g.finishedAt,
+	}
+
+	switch g.state {
+	case Finished:
+		rec.State = storage.GameFinished
+		for _, p := range g.participants {
+			rec.Victors = append(rec.Victors, playerRecord(p))
+		}
+	default:
+		rec.State = storage.GameCancelled
+	}
+
+	if rec.FinishedAt.IsZero() {
+		rec.FinishedAt = time.Now()
+	}
+
+	for _, a := range g.awards {
+		rec.Awards = append(rec.Awards, storage.AwardRecord{
+			Slot:   a.Slot,
+			Prize:  a.Prize,
+			Place:  a.Place,
+			Player: playerRecord(a.Participant),
+			Status: string(a.Status),
+
+			Deadline: a.Deadline,
+		})
+	}
+
+	for _, d := range g.disqualified {
+		rr := storage.RerollRecord{Revoked: playerRecord(d.Revoked)}
+		if d.Replacement != nil {
+			replacement := playerRecord(d.Replacement)
+			rr.Replacement = &replacement
+		}
+
+		rec.Rerolls = append(rec.Rerolls, rr)
+	}
+
+	for _, p := range g.entrants {
+		rec.Entrants = append(rec.Entrants, playerRecord(p))
+	}
+
+	for _, e := range g.eliminations {
+		er := storage.EliminationRecord{
+			Day:    e.Day,
+			Player: playerRecord(e.Participant),
+			Phrase: e.Phrase,
+		}
+
+		if e.Killer != nil {
+			killer := playerRecord(e.Killer)
+			er.Killer = &killer
+		}
+
+		rec.Eliminations = append(rec.Eliminations, er)
+	}
+
+	return rec
+}
+
+func (g *Game) saveRecord() {
+	g.Lock()
+	entered := len(g.entrants)
+	g.Unlock()
+
+	if g.Store == nil || entered == 0 {
+		return
+	}
+
+	rec := g.Record()
+	if err := g.Store.SaveGame(rec); err != nil {
+		g.logMessage(log.ErrorLevel, "unable to save game %v: %v", rec.ID, err)
+		return
+	}
+
+	g.logMessage(log.InfoLevel, "saved game %v", rec.ID)
+}
+
+func playerRecord(p *Participant) storage.PlayerRecord {
+	if p == nil || p.Member == nil || p.User == nil {
+		return storage.PlayerRecord{}
+	}
+
+	return storage.PlayerRecord{UserID: p.User.ID, Name: p.DisplayName()}
+}
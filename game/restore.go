@@ -0,0 +1,118 @@
+package game
+
+import (
+	"github.com/bwmarrin/discordgo"
+	"github.com/deadloct/bitheroes-hg-bot/storage"
+	log "github.com/sirupsen/logrus"
+)
+
+// RestoreGame archives a finished game rebuilt from its record, so its victors
+// can still be rerolled and its prizes claimed after the bot restarts. A game
+// that is still archived is returned as is.
+func (m *Manager) RestoreGame(rec *storage.GameRecord, store storage.Store) *Game {
+	m.Lock()
+	if g, ok := m.archive[rec.ID]; ok {
+		m.Unlock()
+		return g
+	}
+
+	g := restoreGame(rec, GameConfig{
+		Channel: &discordgo.Channel{ID: rec.ChannelID},
+		Guild:   &discordgo.Guild{ID: rec.GuildID},
+		Sender:  NewDiscordSender(m.session, rec.ChannelID),
+		Session: m.session,
+		Store:   store,
+	})
+
+	m.archive[rec.ID] = g
+	m.Unlock()
+
+	g.resumeClaims()
+	return g
+}
+
+// restoreGame fills in cfg from rec and rebuilds the standings and awards.
+// Ties are broken again when a reroll needs the ranking, so tributes who were
+// eliminated on the same day may be ranked differently than in the live game.
+func restoreGame(rec *storage.GameRecord, cfg GameConfig) *Game {
+	participants := make(map[storage.PlayerRecord]*Participant) // clones share a user ID but not a name
+	participant := func(pr storage.PlayerRecord) *Participant {
+		if p, ok := participants[pr]; ok {
+			return p
+		}
+
+		p := NewParticipant(&discordgo.Member{GuildID: rec.GuildID, User: &discordgo.User{ID: pr.UserID, Username: pr.Name}})
+		participants[pr] = p
+		return p
+	}
+
+	cfg.ClaimDeadline = rec.Config.ClaimDeadline
+	cfg.Clone = rec.Config.Clone
+	cfg.DayDelay = rec.Config.DayDelay
+	cfg.Delay = rec.Config.Delay
+	cfg.MinimumTier = rec.Config.MinimumTier
+	cfg.Prizes = rec.Config.Prizes
+	cfg.ProvablyFair = rec.Config.ProvablyFair
+	cfg.Seed = rec.Config.Seed
+	cfg.Sponsor = rec.Sponsor
+	cfg.StartedBy = participant(rec.StartedBy)
+	cfg.SurvivalWeights = rec.Config.Weights
+	cfg.TargetDuration = rec.Config.Target
+	cfg.VictorCount = rec.Config.VictorCount
+
+	g := NewGame(cfg)
+	g.introMessage = &discordgo.Message{ID: rec.ID, ChannelID: rec.ChannelID}
+	g.state = Finished
+	g.createdAt = rec.CreatedAt
+	g.startedAt = rec.StartedAt
+	g.finishedAt = rec.FinishedAt
+
+	for _, pr := range rec.Entrants {
+		g.entrants = append(g.entrants, participant(pr))
+	}
+
+	for _, pr := range rec.Victors {
+		g.participants = append(g.participants, participant(pr))
+	}
+
+	for _, er := range rec.Eliminations {
+		e := Elimination{Day: er.Day, Participant: participant(er.Player), Phrase: er.Phrase}
+		if er.Killer != nil {
+			e.Killer = participant(*er.Killer)
+		}
+
+		g.eliminations = append(g.eliminations, e)
+	}
+
+	for _, ar := range rec.Awards {
+		g.awards = append(g.awards, Award{
+			Slot:        ar.Slot,
+			Prize:       ar.Prize,
+			Place:       ar.Place,
+			Participant: participant(ar.Player),
+			Status:      ClaimStatus(ar.Status),
+			Deadline:    ar.Deadline,
+		})
+	}
+
+	for _, rr := range rec.Rerolls {
+		d := disqualification{Revoked: participant(rr.Revoked)}
+		if rr.Replacement != nil {
+			d.Replacement = participant(*rr.Replacement)
+		}
+
+		g.disqualified = append(g.disqualified, d)
+	}
+
+	// Rerolls pass prizes down the ranking, which only the awards need.
+	if len(g.awards) > 0 {
+		ranking, err := g.ranking()
+		if err != nil {
+			g.logMessage(log.ErrorLevel, "unable to rank restored game %v, its prizes can't be rerolled: %v", rec.ID, err)
+		}
+
+		g.ranked = ranking
+	}
+
+	return g
+}
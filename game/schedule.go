@@ -0,0 +1,77 @@
+package game
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/deadloct/bitheroes-hg-bot/settings"
+	log "github.com/sirupsen/logrus"
+)
+
+var ErrStartTooLate = fmt.Errorf("entries can't stay open for more than %v minutes", settings.MaximumStartDelay)
+
+// BeginNow closes entries and starts the game right away.
+func (g *Game) BeginNow() error {
+	_, err := g.reschedule(func(time.Time) time.Time { return time.Now() })
+	return err
+}
+
+// Extend keeps entries open d longer and returns when they now close.
+func (g *Game) Extend(d time.Duration) (time.Time, error) {
+	return g.reschedule(func(closes time.Time) time.Time { return closes.Add(d) })
+}
+
+// Shorten closes entries d sooner and returns when they now close. Shortening
+// past the present starts the game right away.
+func (g *Game) Shorten(d time.Duration) (time.Time, error) {
+	return g.reschedule(func(closes time.Time) time.Time {
+		if now := time.Now(); closes.Add(-d).Before(now) {
+			return now
+		}
+
+		return closes.Add(-d)
+	})
+}
+
+// reschedule moves the close of entries to next(current close) and wakes
+// awaitStart to rearm its timers.
+func (g *Game) reschedule(next func(time.Time) time.Time) (time.Time, error) {
+	g.Lock()
+	if g.state != NotStarted || g.closing || g.entriesClose.IsZero() {
+		g.Unlock()
+		return time.Time{}, ErrEntriesClosed
+	}
+
+	closes := next(g.entriesClose)
+	if closes.Sub(time.Now()) > time.Duration(settings.MaximumStartDelay*float64(time.Minute)) {
+		g.Unlock()
+		return time.Time{}, ErrStartTooLate
+	}
+
+	g.Delay += closes.Sub(g.entriesClose)
+	g.entriesClose = closes
+	g.Unlock()
+
+	g.logMessage(log.InfoLevel, "entries now close at %v", closes)
+
+	// awaitStart only needs to know that something changed, so a pending
+	// signal covers this one too.
+	select {
+	case g.rescheduled <- struct{}{}:
+	default:
+	}
+
+	return closes, nil
+}
+
+// announceSchedule tells the channel when entries close after a reschedule.
+func (g *Game) announceSchedule() {
+	closes := g.EntriesClose()
+	if !closes.After(time.Now()) {
+		return
+	}
+
+	if _, err := g.Sender.SendQuoted(fmt.Sprintf("The Gamemakers have changed the schedule. Entries now close <t:%v:R>.", closes.Unix())); err != nil {
+		g.logMessage(log.ErrorLevel, "unable to announce the new schedule: %v", err)
+	}
+}
@@ -0,0 +1,44 @@
+package game
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGame_Reschedule(t *testing.T) {
+	g := testGame(GameConfig{
+		Delay: 10 * time.Minute,
+	})
+	closes := time.Now().Add(10 * time.Minute)
+	g.entriesClose = closes
+
+	extended, err := g.Extend(5 * time.Minute)
+	if err != nil || !extended.Equal(closes.Add(5*time.Minute)) || g.Delay != 15*time.Minute {
+		t.Errorf("expected extending to add 5 minutes but got %v, delay %v, error %v", extended.Sub(closes), g.Delay, err)
+	}
+
+	if len(g.rescheduled) != 1 {
+		t.Error("expected awaitStart to be woken")
+	}
+
+	if _, err := g.Extend(5 * time.Hour); !errors.Is(err, ErrStartTooLate) {
+		t.Errorf("expected entries to stay open no longer than the maximum start delay but got %v", err)
+	}
+
+	shortened, err := g.Shorten(time.Hour)
+	if err != nil || time.Until(shortened) > time.Second {
+		t.Errorf("expected shortening past now to start right away but got %v, error %v", time.Until(shortened), err)
+	}
+
+	// Entries are closing once the start timer fired, even before run starts the game.
+	g.closing = true
+	if _, err := g.Extend(time.Minute); !errors.Is(err, ErrEntriesClosed) {
+		t.Errorf("expected a closing game not to be rescheduled but got %v", err)
+	}
+
+	g.state = Started
+	if err := g.BeginNow(); !errors.Is(err, ErrEntriesClosed) {
+		t.Errorf("expected a started game not to be rescheduled but got %v", err)
+	}
+}
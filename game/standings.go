@@ -0,0 +1,79 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/deadloct/bitheroes-hg-bot/lib"
+	"github.com/deadloct/bitheroes-hg-bot/settings"
+)
+
+// Standing is one place in the final results. Tributes eliminated on the same day tie.
+type Standing struct {
+	Place        int
+	Participants []*Participant
+}
+
+// Standings ranks every tribute, victors first, using standard competition
+// ranking: two tributes tied for 2nd are followed by 4th. Clones are ranked
+// separately from the entrant they were cloned from.
+func (g *Game) Standings() []Standing {
+	g.Lock()
+	defer g.Unlock()
+
+	return g.standings()
+}
+
+// standings must be called with the lock held.
+func (g *Game) standings() []Standing {
+	var standings []Standing
+	if g.state == Finished && len(g.participants) > 0 {
+		standings = append(standings, Standing{
+			Place:        1,
+			Participants: append([]*Participant(nil), g.participants...),
+		})
+	}
+
+	ranked := len(g.participants)
+	for i := len(g.eliminations) - 1; i >= 0; {
+		day := g.eliminations[i].Day
+		standing := Standing{Place: ranked + 1}
+		for ; i >= 0 && g.eliminations[i].Day == day; i-- {
+			standing.Participants = append(standing.Participants, g.eliminations[i].Participant)
+		}
+
+		ranked += len(standing.Participants)
+		standings = append(standings, standing)
+	}
+
+	return standings
+}
+
+// formatStandings lists the top settings.StandingsPlaces places, one line per place.
+func formatStandings(standings []Standing, name func(*Participant) string) []string {
+	var lines []string
+	for _, s := range standings {
+		if s.Place > settings.StandingsPlaces {
+			break
+		}
+
+		var names []string
+		for i, p := range s.Participants {
+			if i == settings.StandingsPlaces {
+				names = append(names, fmt.Sprintf("and %v more", len(s.Participants)-i))
+				break
+			}
+
+			names = append(names, name(p))
+		}
+
+		tie := ""
+		if len(s.Participants) > 1 {
+			tie = " (tie)"
+		}
+
+		lines = append(lines, fmt.Sprintf("%v%v: %v", lib.Ordinal(s.Place), tie, strings.Join(names, ", ")))
+	}
+
+	return lines
+}
@@ -0,0 +1,64 @@
+package game
+
+import "time"
+
+// Status is a snapshot of a game for /hg-status.
+type Status struct {
+	State        GameState
+	Sponsor      string
+	StartedBy    *Participant
+	EntriesClose time.Time
+	Entrants     int
+	Alive        int // tributes left including clones, once started
+	Day          int // 1-based day being simulated, 0 before the first
+	Paused       bool
+}
+
+func (s GameState) String() string {
+	switch s {
+	case NotStarted:
+		return "waiting for tributes"
+	case Started:
+		return "in the arena"
+	case Finished:
+		return "finished"
+	case Cancelled:
+		return "cancelled"
+	default:
+		return "unknown"
+	}
+}
+
+// Entrants returns the tributes in registration order, without clones.
+func (g *Game) Entrants() []*Participant {
+	g.Lock()
+	defer g.Unlock()
+
+	if g.state == NotStarted {
+		return append([]*Participant(nil), g.participants...)
+	}
+
+	return append([]*Participant(nil), g.entrants...)
+}
+
+func (g *Game) Status() Status {
+	g.Lock()
+	defer g.Unlock()
+
+	s := Status{
+		State:        g.state,
+		Sponsor:      g.Sponsor,
+		StartedBy:    g.StartedBy,
+		EntriesClose: g.entriesClose,
+		Entrants:     len(g.participants),
+		Day:          g.day,
+		Paused:       g.resumed != nil,
+	}
+
+	if g.state != NotStarted {
+		s.Entrants = len(g.entrants)
+		s.Alive = len(g.participants)
+	}
+
+	return s
+}
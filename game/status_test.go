@@ -0,0 +1,52 @@
+package game
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGame_Status(t *testing.T) {
+	jp, members := testSetupGameRun(t, 10, 1)
+	g := testGame(GameConfig{
+		Clone:           2,
+		PhraseGenerator: jp,
+		VictorCount:     2,
+	})
+
+	for _, m := range members {
+		if _, err := g.Enter("123", NewParticipant(m)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if s := g.Status(); s.State != NotStarted || s.Entrants != 10 || s.Alive != 0 || s.Sponsor != "Sponsor" {
+		t.Errorf("expected a waiting game with 10 entrants but got %+v", s)
+	}
+
+	// Interaction goroutines read the status while the game starts.
+	done := make(chan struct{})
+	go func() {
+		g.run(context.Background())
+		close(done)
+	}()
+
+	for running := true; running; {
+		select {
+		case <-done:
+			running = false
+		default:
+			if s := g.Status(); s.State != NotStarted && s.Entrants != 10 {
+				t.Fatalf("expected a started game to report its 10 entrants but got %+v", s)
+			}
+		}
+	}
+
+	if s := g.Status(); s.State != Finished || s.Entrants != 10 || s.Alive != 2 || s.Day == 0 {
+		t.Errorf("expected a finished game with 2 of 10 entrants left but got %+v", s)
+	}
+
+	// Clones aren't listed as entrants.
+	if entrants := g.Entrants(); len(entrants) != 10 || entrants[0].User.ID != members[0].User.ID {
+		t.Errorf("expected the 10 entrants in registration order but got %v", entrants)
+	}
+}
@@ -0,0 +1,272 @@
+package game
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/deadloct/bitheroes-hg-bot/storage"
+	log "github.com/sirupsen/logrus"
+)
+
+// deathScale turns survival weights into integer odds of dying for the
+// randomizer. A tribute with weight w is 1/w times as likely to die as one
+// with weight 1.
+const deathScale = 1000
+
+// Weigher is a hook that changes a tribute's odds of surviving each day. A
+// weight of 1 keeps the usual odds, and the weights of every weigher multiply.
+type Weigher interface {
+	Weight(p *Participant) float64
+	Describe() string // listed in the intro
+}
+
+// BoosterWeight gives server boosters a different survival weight.
+type BoosterWeight float64
+
+func (w BoosterWeight) Weight(p *Participant) float64 {
+	if p.Member != nil && p.Member.PremiumSince != nil {
+		return float64(w)
+	}
+
+	return 1
+}
+
+func (w BoosterWeight) Describe() string {
+	return fmt.Sprintf("Server boosters have %v of the usual odds of surviving each day.", percent(float64(w)))
+}
+
+// RoleWeights maps role IDs to survival weights. Tributes with several of the
+// roles get the highest weight.
+type RoleWeights map[string]float64
+
+func (w RoleWeights) Weight(p *Participant) float64 {
+	if p.Member == nil {
+		return 1
+	}
+
+	weight := 1.0
+	found := false
+	for _, r := range p.Member.Roles {
+		if rw, ok := w[r]; ok && (!found || rw > weight) {
+			weight, found = rw, true
+		}
+	}
+
+	return weight
+}
+
+func (w RoleWeights) Describe() string {
+	roles := slices.SortedFunc(maps.Keys(w), func(a, b string) int { return cmp.Compare(w[b], w[a]) })
+
+	var list []string
+	for _, r := range roles {
+		list = append(list, fmt.Sprintf("<@&%v> %v", r, percent(w[r])))
+	}
+
+	return fmt.Sprintf("Tributes with these roles have a share of the usual odds of surviving each day: %v.", strings.Join(list, ", "))
+}
+
+// PityWeight helps tributes who keep losing. Tributes who entered at least
+// Losses finished games in a row without winning get Bonus.
+type PityWeight struct {
+	Losses int
+	Bonus  float64
+
+	streaks map[string]int // maps user ID to their current losing streak
+}
+
+// NewPityWeight counts losing streaks in recs, which must be newest first like
+// storage.Store returns them.
+func NewPityWeight(losses int, bonus float64, recs []*storage.GameRecord) *PityWeight {
+	pw := &PityWeight{Losses: losses, Bonus: bonus, streaks: make(map[string]int)}
+	ended := make(map[string]struct{}) // users whose streak was broken by a win
+
+	for _, rec := range recs {
+		if rec.State != storage.GameFinished {
+			continue
+		}
+
+		for _, v := range rec.CurrentVictors() {
+			ended[v.UserID] = struct{}{}
+		}
+
+		counted := make(map[string]struct{}) // clones share a user ID
+		for _, e := range rec.Entrants {
+			if _, ok := ended[e.UserID]; ok {
+				continue
+			}
+
+			if _, ok := counted[e.UserID]; !ok {
+				counted[e.UserID] = struct{}{}
+				pw.streaks[e.UserID]++
+			}
+		}
+	}
+
+	return pw
+}
+
+func (w *PityWeight) Weight(p *Participant) float64 {
+	if w.streaks[p.User.ID] >= w.Losses {
+		return w.Bonus
+	}
+
+	return 1
+}
+
+func (w *PityWeight) Describe() string {
+	return fmt.Sprintf("Tributes who lost their last %v in a row have %v of the usual odds of surviving each day.", pluralize(w.Losses, "game"), percent(w.Bonus))
+}
+
+// weighers returns the winner policy's handicap along with the other hooks.
+func (g *Game) weighers() []Weigher {
+	var weighers []Weigher
+	if g.Winners.IsActive() && g.Winners.HandicapDays > 0 && g.Winners.HandicapWeight < 1 {
+		weighers = append(weighers, g.Winners)
+	}
+
+	return append(weighers, g.Weighers...)
+}
+
+// weightRules describes the weighers for the intro.
+func (g *Game) weightRules() []string {
+	var rules []string
+	for _, w := range g.weighers() {
+		rules = append(rules, w.Describe())
+	}
+
+	return rules
+}
+
+// survivalWeights returns the weights of the entrants whose odds differ from
+// the usual, keyed by user ID.
+func (g *Game) survivalWeights(entrants []*Participant) map[string]float64 {
+	weighers := g.weighers()
+	weights := make(map[string]float64)
+	for _, p := range entrants {
+		weight := 1.0
+		for _, w := range weighers {
+			weight *= w.Weight(p)
+		}
+
+		if weight != 1 && weight > 0 {
+			weights[p.User.ID] = weight
+			g.logMessage(log.InfoLevel, "%v survives with weight %v", p.DisplayFullName(), weight)
+		}
+	}
+
+	if len(weights) == 0 {
+		return nil
+	}
+
+	return weights
+}
+
+func percent(weight float64) string {
+	return fmt.Sprintf("%v%%", strconv.FormatFloat(math.Round(weight*1000)/10, 'f', -1, 64))
+}
+
+// deathWeight must only be used with SurvivalWeights set.
+func (g *Game) deathWeight(p *Participant) int {
+	w, ok := g.SurvivalWeights[p.User.ID]
+	if !ok || w <= 0 {
+		return deathScale
+	}
+
+	return max(1, int(math.Round(deathScale/w)))
+}
+
+// drawDead picks killCount distinct indexes into participants in the order
+// they die. Without survival weights every tribute is equally likely to die,
+// which keeps the draws of games from before weights existed unchanged.
+func (g *Game) drawDead(participants []*Participant, killCount int) ([]int, error) {
+	dead := make(map[int]struct{})
+	var deadOrder []int
+
+	if len(g.SurvivalWeights) == 0 {
+		for i := 0; i < killCount; i++ {
+			for {
+				toDie, err := g.Randomizer.GetRandomInt(0, len(participants))
+				if err != nil {
+					return nil, err
+				}
+
+				if _, alreadyDead := dead[toDie]; !alreadyDead {
+					dead[toDie] = struct{}{}
+					deadOrder = append(deadOrder, toDie)
+					break
+				}
+			}
+		}
+
+		return deadOrder, nil
+	}
+
+	for i := 0; i < killCount; i++ {
+		var total int
+		for j, p := range participants {
+			if _, alreadyDead := dead[j]; !alreadyDead {
+				total += g.deathWeight(p)
+			}
+		}
+
+		n, err := g.Randomizer.GetRandomInt(0, total)
+		if err != nil {
+			return nil, err
+		}
+
+		for j, p := range participants {
+			if _, alreadyDead := dead[j]; alreadyDead {
+				continue
+			}
+
+			if n -= g.deathWeight(p); n < 0 {
+				dead[j] = struct{}{}
+				deadOrder = append(deadOrder, j)
+				break
+			}
+		}
+	}
+
+	return deadOrder, nil
+}
+
+// FormatWeights is the hg-verify -weights flag value for weights.
+func FormatWeights(weights map[string]float64) string {
+	var pairs []string
+	for id, w := range weights {
+		pairs = append(pairs, fmt.Sprintf("%v=%v", id, strconv.FormatFloat(w, 'f', -1, 64)))
+	}
+
+	slices.Sort(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// ParseWeights is the inverse of FormatWeights.
+func ParseWeights(str string) (map[string]float64, error) {
+	if str == "" {
+		return nil, nil
+	}
+
+	weights := make(map[string]float64)
+	for _, pair := range strings.Split(str, ",") {
+		id, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("weight %q isn't user=weight", pair)
+		}
+
+		w, err := strconv.ParseFloat(value, 64)
+		if err != nil || w <= 0 {
+			return nil, fmt.Errorf("weight of %v must be a positive number", id)
+		}
+
+		weights[id] = w
+	}
+
+	return weights, nil
+}
@@ -0,0 +1,92 @@
+package game
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/deadloct/bitheroes-hg-bot/storage"
+)
+
+func TestNewPityWeight(t *testing.T) {
+	entrants := func(ids ...string) []storage.PlayerRecord {
+		var players []storage.PlayerRecord
+		for _, id := range ids {
+			players = append(players, storage.PlayerRecord{UserID: id})
+		}
+		return players
+	}
+
+	// Newest first, "loser" entered three games in a row without winning while
+	// "winner" won the middle one. Clones of "loser" count once per game.
+	recs := []*storage.GameRecord{
+		{State: storage.GameFinished, Entrants: entrants("loser", "loser", "winner", "other"), Victors: entrants("other")},
+		{State: storage.GameCancelled, Entrants: entrants("loser")},
+		{State: storage.GameFinished, Entrants: entrants("loser", "winner"), Victors: entrants("winner")},
+		{State: storage.GameFinished, Entrants: entrants("loser", "winner"), Victors: entrants("winner")},
+	}
+
+	pw := NewPityWeight(3, 1.5, recs)
+	tests := map[string]float64{"loser": 1.5, "winner": 1, "other": 1, "newcomer": 1}
+	for id, want := range tests {
+		if got := pw.Weight(NewParticipant(&discordgo.Member{User: &discordgo.User{ID: id}})); got != want {
+			t.Errorf("%v: expected weight %v but got %v", id, want, got)
+		}
+	}
+}
+
+func TestRoleWeights_Weight(t *testing.T) {
+	w := RoleWeights{"veteran": 0.5, "champion": 2}
+	tests := []struct {
+		Name        string
+		Participant *Participant
+		Weight      float64
+	}{
+		{Name: "highest role", Participant: NewParticipant(&discordgo.Member{Roles: []string{"veteran", "champion"}}), Weight: 2},
+		{Name: "no roles", Participant: NewParticipant(&discordgo.Member{}), Weight: 1},
+		{Name: "no member", Participant: &Participant{}, Weight: 1},
+	}
+
+	for _, test := range tests {
+		if got := w.Weight(test.Participant); got != test.Weight {
+			t.Errorf("%v: expected weight %v but got %v", test.Name, test.Weight, got)
+		}
+	}
+}
+
+func TestGame_SurvivalWeights(t *testing.T) {
+	boosted := time.Now()
+	members := []*discordgo.Member{
+		{User: &discordgo.User{ID: "booster"}, PremiumSince: &boosted},
+		{User: &discordgo.User{ID: "both"}, PremiumSince: &boosted, Roles: []string{"vip", "helper"}},
+		{User: &discordgo.User{ID: "helper"}, Roles: []string{"helper"}},
+		{User: &discordgo.User{ID: "plain"}},
+	}
+
+	var participants []*Participant
+	for _, m := range members {
+		participants = append(participants, NewParticipant(m))
+	}
+
+	g := testGame(GameConfig{
+		Weighers: []Weigher{BoosterWeight(1.1), RoleWeights{"vip": 2, "helper": 1.25}},
+	})
+
+	weights := g.survivalWeights(participants)
+	want := map[string]float64{"booster": 1.1, "both": 2.2, "helper": 1.25}
+	if len(weights) != len(want) {
+		t.Fatalf("expected weights %v but got %v", want, weights)
+	}
+
+	for id, w := range want {
+		if diff := weights[id] - w; diff > 1e-9 || diff < -1e-9 {
+			t.Errorf("%v: expected weight %v but got %v", id, w, weights[id])
+		}
+	}
+
+	rules := g.weightRules()
+	if len(rules) != 2 || !strings.Contains(rules[0], "110%") || !strings.Contains(rules[1], "<@&vip> 200%, <@&helper> 125%") {
+		t.Errorf("expected the booster and role weights in the intro but got %v", rules)
+	}
+}
@@ -0,0 +1,150 @@
+package game
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/deadloct/bitheroes-hg-bot/storage"
+	log "github.com/sirupsen/logrus"
+)
+
+// WinnerPolicy keeps the same tributes from winning week after week. Victors
+// can be barred from entering for a while after a win, or enter with lower odds
+// of surviving each day.
+type WinnerPolicy struct {
+	CooldownDays   int     // victors can't enter for this many days after winning
+	CooldownGames  int     // victors can't enter until this many more games have finished
+	HandicapDays   int     // victors of the last HandicapDays enter with HandicapWeight
+	HandicapWeight float64 // survival weight of handicapped tributes, between 0 and 1
+
+	cooldowns map[string]string // maps user ID to why they can't enter
+	handicaps map[string]struct{}
+}
+
+// IsActive is false for a nil policy or one that changes nothing.
+func (p *WinnerPolicy) IsActive() bool {
+	return p != nil && (p.CooldownDays > 0 || p.CooldownGames > 0 || (p.HandicapDays > 0 && p.HandicapWeight < 1))
+}
+
+// ApplyHistory finds the guild's recent victors in recs, which must be newest
+// first like storage.Store returns them.
+func (p *WinnerPolicy) ApplyHistory(recs []*storage.GameRecord, now time.Time) {
+	p.cooldowns = make(map[string]string)
+	p.handicaps = make(map[string]struct{})
+
+	var finished int
+	for _, rec := range recs {
+		if rec.State != storage.GameFinished {
+			continue
+		}
+
+		finished++
+		age := now.Sub(rec.FinishedAt)
+		for _, v := range rec.CurrentVictors() {
+			if _, ok := p.cooldowns[v.UserID]; ok {
+				continue
+			}
+
+			switch {
+			case p.CooldownGames > 0 && finished <= p.CooldownGames:
+				p.cooldowns[v.UserID] = fmt.Sprintf(
+					"you won one of the last %v, so you can enter again once %v finished",
+					pluralize(p.CooldownGames, "game"), pluralize(p.CooldownGames-finished+1, "more game has", "more games have"))
+			case p.CooldownDays > 0 && age < days(p.CooldownDays):
+				p.cooldowns[v.UserID] = fmt.Sprintf(
+					"you won a game in the last %v, so you can enter again <t:%v:R>",
+					pluralize(p.CooldownDays, "day"), rec.FinishedAt.Add(days(p.CooldownDays)).Unix())
+			}
+
+			if p.HandicapDays > 0 && p.HandicapWeight < 1 && age < days(p.HandicapDays) {
+				p.handicaps[v.UserID] = struct{}{}
+			}
+		}
+	}
+}
+
+// Cooldown returns why userID can't enter yet.
+func (p *WinnerPolicy) Cooldown(userID string) (string, bool) {
+	if p == nil {
+		return "", false
+	}
+
+	reason, ok := p.cooldowns[userID]
+	return reason, ok
+}
+
+func (p *WinnerPolicy) Handicapped(userID string) bool {
+	if p == nil {
+		return false
+	}
+
+	_, ok := p.handicaps[userID]
+	return ok
+}
+
+// HandicapNotice tells a handicapped tribute why their odds are lower.
+func (p *WinnerPolicy) HandicapNotice() string {
+	return fmt.Sprintf(
+		"You won a game in the last %v, so the Gamemakers give you %v of the usual odds of surviving each day.",
+		pluralize(p.HandicapDays, "day"), percent(p.HandicapWeight))
+}
+
+// Rules describes the cooldowns for the intro. The handicap is listed with the
+// other survival weights.
+func (p *WinnerPolicy) Rules() []string {
+	if p == nil {
+		return nil
+	}
+
+	var rules []string
+	switch {
+	case p.CooldownGames > 0 && p.CooldownDays > 0:
+		rules = append(rules, fmt.Sprintf("Victors of the last %v or the last %v can't enter.", pluralize(p.CooldownGames, "game"), pluralize(p.CooldownDays, "day")))
+	case p.CooldownGames > 0:
+		rules = append(rules, fmt.Sprintf("Victors of the last %v can't enter.", pluralize(p.CooldownGames, "game")))
+	case p.CooldownDays > 0:
+		rules = append(rules, fmt.Sprintf("Victors of the last %v can't enter.", pluralize(p.CooldownDays, "day")))
+	}
+
+	return rules
+}
+
+// Weight lowers the survival odds of handicapped victors.
+func (p *WinnerPolicy) Weight(participant *Participant) float64 {
+	if p.Handicapped(participant.User.ID) {
+		return p.HandicapWeight
+	}
+
+	return 1
+}
+
+func (p *WinnerPolicy) Describe() string {
+	return fmt.Sprintf("Victors of the last %v have %v of the usual odds of surviving each day.", pluralize(p.HandicapDays, "day"), percent(p.HandicapWeight))
+}
+
+func days(n int) time.Duration {
+	return time.Duration(n) * 24 * time.Hour
+}
+
+// pluralize formats n with the singular or plural form. The plural defaults to singular+"s".
+func pluralize(n int, singular string, plural ...string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %v", singular)
+	}
+
+	if len(plural) > 0 {
+		return fmt.Sprintf("%v %v", n, plural[0])
+	}
+
+	return fmt.Sprintf("%v %vs", n, singular)
+}
+
+// notifyHandicap DMs a tribute who reacted to the intro and entered with lower
+// odds. Buttons answer ephemerally instead. Like notifyIneligible it's only
+// called once every lock is released, since the DM is a request to Discord.
+func (g *Game) notifyHandicap(p *Participant) {
+	msg := fmt.Sprintf("You've entered the Hunger Games event sponsored by %v in <#%v>. %v", g.Sponsor, g.Channel.ID, g.Winners.HandicapNotice())
+	if err := g.Sender.SendDM(p.User, msg); err != nil {
+		g.logMessage(log.ErrorLevel, "unable to tell %v about their handicap: %v", p.DisplayFullName(), err)
+	}
+}
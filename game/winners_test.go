@@ -0,0 +1,214 @@
+package game
+
+import (
+	"context"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/deadloct/bitheroes-hg-bot/lib"
+	"github.com/deadloct/bitheroes-hg-bot/settings"
+	"github.com/deadloct/bitheroes-hg-bot/storage"
+)
+
+func TestWinnerPolicy_ApplyHistory(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	recs := []*storage.GameRecord{
+		{State: storage.GameCancelled, FinishedAt: now.Add(-time.Hour), Victors: []storage.PlayerRecord{{UserID: "cancelled"}}},
+		{State: storage.GameFinished, FinishedAt: now.Add(-24 * time.Hour), Victors: []storage.PlayerRecord{{UserID: "yesterday"}}},
+		{State: storage.GameFinished, FinishedAt: now.Add(-10 * 24 * time.Hour), Victors: []storage.PlayerRecord{{UserID: "last-week"}}},
+		{State: storage.GameFinished, FinishedAt: now.Add(-60 * 24 * time.Hour), Victors: []storage.PlayerRecord{{UserID: "long-ago"}}},
+	}
+
+	tests := []struct {
+		Name       string
+		Policy     WinnerPolicy
+		Cooldown   []string
+		Handicaps  []string
+		ReasonPart string
+	}{
+		{Name: "games", Policy: WinnerPolicy{CooldownGames: 2}, Cooldown: []string{"yesterday", "last-week"}, ReasonPart: "once 2 more games have finished"},
+		{Name: "days", Policy: WinnerPolicy{CooldownDays: 7}, Cooldown: []string{"yesterday"}, ReasonPart: "in the last 7 days"},
+		{Name: "handicap", Policy: WinnerPolicy{HandicapDays: 30, HandicapWeight: 0.5}, Handicaps: []string{"yesterday", "last-week"}},
+		{Name: "handicap at full odds", Policy: WinnerPolicy{HandicapDays: 30, HandicapWeight: 1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			p := test.Policy
+			p.ApplyHistory(recs, now)
+
+			for _, id := range []string{"cancelled", "yesterday", "last-week", "long-ago"} {
+				reason, cooldown := p.Cooldown(id)
+				if want := slices.Contains(test.Cooldown, id); cooldown != want {
+					t.Errorf("%v: expected cooldown %v but got %v", id, want, cooldown)
+				}
+
+				if cooldown && id == test.Cooldown[0] && !strings.Contains(reason, test.ReasonPart) {
+					t.Errorf("%v: expected the reason to mention %q but got %q", id, test.ReasonPart, reason)
+				}
+
+				if want := slices.Contains(test.Handicaps, id); p.Handicapped(id) != want {
+					t.Errorf("%v: expected handicap %v but got %v", id, want, p.Handicapped(id))
+				}
+			}
+		})
+	}
+}
+
+func TestGame_RegisterUser_WinnerCooldown(t *testing.T) {
+	sender := &BufferSender{}
+	policy := &WinnerPolicy{CooldownGames: 1, HandicapDays: 30, HandicapWeight: 0.5}
+	policy.ApplyHistory([]*storage.GameRecord{{
+		State:      storage.GameFinished,
+		FinishedAt: time.Now().Add(-time.Hour),
+		Victors:    []storage.PlayerRecord{{UserID: "1"}},
+	}, {
+		State:      storage.GameFinished,
+		FinishedAt: time.Now().Add(-2 * time.Hour),
+		Victors:    []storage.PlayerRecord{{UserID: "2"}},
+	}}, time.Now())
+
+	g := testGame(GameConfig{
+		Sender:  sender,
+		Winners: policy,
+	})
+
+	emoji := settings.GetEmoji(settings.EmojiParticipant).Name
+	g.RegisterUser("123", emoji, NewParticipant(&discordgo.Member{User: &discordgo.User{ID: "1", Username: "champion"}}))
+	g.RegisterUser("123", emoji, NewParticipant(&discordgo.Member{User: &discordgo.User{ID: "2", Username: "runner"}}))
+
+	if len(g.participants) != 1 || g.participants[0].User.ID != "2" {
+		t.Fatalf("expected only the earlier victor to enter but got %v", g.participants)
+	}
+
+	// Keeping the reaction on the intro doesn't get around the cooldown when entries close.
+	g.mergeEntrants([]*discordgo.User{{ID: "1", Username: "champion"}, {ID: "2", Username: "runner"}},
+		func(u *discordgo.User) *discordgo.Member { return &discordgo.Member{User: u} })
+	if len(g.participants) != 1 {
+		t.Fatalf("expected the cooled down victor not to be reconciled into the game but got %v", g.participants)
+	}
+
+	if len(sender.dms) != 2 || !strings.Contains(sender.dms[0], "can enter again") || !strings.Contains(sender.dms[1], "50% of the usual odds") {
+		t.Errorf("expected the cooldown and handicap to be explained but got %v", sender.dms)
+	}
+
+	if weights := g.survivalWeights(g.participants); weights["2"] != 0.5 {
+		t.Errorf("expected the handicapped victor to survive with weight 0.5 but got %v", weights)
+	}
+}
+
+func TestManager_ReactionHandler_HandicapDMWithoutLock(t *testing.T) {
+	policy := &WinnerPolicy{HandicapDays: 30, HandicapWeight: 0.5}
+	policy.ApplyHistory([]*storage.GameRecord{{
+		State:      storage.GameFinished,
+		FinishedAt: time.Now().Add(-time.Hour),
+		Victors:    []storage.PlayerRecord{{UserID: "1"}},
+	}}, time.Now())
+
+	m := &Manager{games: make(map[string]*RunningGame), archive: make(map[string]*Game)}
+	var g *Game
+	var locked bool
+	sender := &BufferSender{OnDM: func() {
+		if !m.TryLock() {
+			locked = true
+		} else {
+			m.Unlock()
+		}
+
+		if !g.TryLock() {
+			locked = true
+		} else {
+			g.Unlock()
+		}
+	}}
+
+	g = testGame(GameConfig{
+		Sender:  sender,
+		Winners: policy,
+	})
+	m.games["123"] = &RunningGame{Game: g}
+
+	m.ReactionHandler(nil, &discordgo.MessageReactionAdd{
+		MessageReaction: &discordgo.MessageReaction{ChannelID: "123", MessageID: "123", Emoji: discordgo.Emoji{Name: settings.GetEmoji(settings.EmojiParticipant).Name}},
+		Member:          &discordgo.Member{User: &discordgo.User{ID: "1", Username: "champion"}},
+	})
+
+	if len(g.participants) != 1 || len(sender.dms) != 1 || locked {
+		t.Errorf("expected the handicapped victor to enter and be told without any lock held but got %v, locked %v", sender.dms, locked)
+	}
+}
+
+func TestGame_DrawDead_Weighted(t *testing.T) {
+	_, members := testSetupGameRun(t, 10, 1)
+	var participants []*Participant
+	for _, m := range members {
+		participants = append(participants, NewParticipant(m))
+	}
+
+	g := NewGame(GameConfig{
+		Randomizer:      lib.NewSeededRandomizer(7),
+		SurvivalWeights: map[string]float64{"0-0": 100, "1-0": 0.01},
+	})
+
+	deaths := make(map[string]int)
+	for i := 0; i < 1000; i++ {
+		order, err := g.drawDead(participants, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		deaths[participants[order[0]].User.ID]++
+	}
+
+	if deaths["0-0"] > 20 {
+		t.Errorf("expected the favored tribute to rarely die but they died %v times", deaths["0-0"])
+	}
+
+	if deaths["1-0"] < 900 {
+		t.Errorf("expected the handicapped tribute to nearly always die but they died %v times", deaths["1-0"])
+	}
+}
+
+func TestReplay_MatchesWeightedGame(t *testing.T) {
+	data, err := os.ReadFile(path.Join("..", settings.DataLocation, "phrases.en.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, members := testSetupGameRun(t, 20, 1)
+	weights := map[string]float64{"0-0": 0.25, "5-0": 0.5}
+	rng := lib.NewSeededRandomizer(42)
+	g := testGame(GameConfig{
+		PhraseGenerator: lib.NewJSONPhrases(data, rng),
+		Randomizer:      rng,
+		Seed:            42,
+		SurvivalWeights: weights,
+		VictorCount:     1,
+	})
+
+	testEnter(g, members)
+
+	live := g.run(context.Background())
+
+	parsed, err := ParseWeights(FormatWeights(weights))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replayed := Replay(ReplayConfig{
+		EntrantIDs:  ParseEntrantsFile(EntrantsFile(g.entrants)),
+		PhraseData:  data,
+		Seed:        42,
+		VictorCount: 1,
+		Weights:     parsed,
+	})
+
+	if len(live) != 1 || len(replayed.participants) != 1 || live[0].User.ID != replayed.participants[0].User.ID {
+		t.Errorf("expected the replay to crown %v but got %v", live, replayed.participants)
+	}
+}
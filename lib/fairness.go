@@ -0,0 +1,19 @@
+package lib
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// SeedCommitment is published before a provably fair game starts. It is the
+// SHA-256 of the seed's decimal representation, so anyone can check it with
+// `echo -n <seed> | sha256sum` once the seed is revealed.
+func SeedCommitment(seed uint64) string {
+	return SHA256Hex([]byte(strconv.FormatUint(seed, 10)))
+}
+
+func SHA256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -12,25 +12,31 @@ type Joke struct {
 }
 
 type JSONJokes struct {
-	jokes   []Joke
-	indexes []int
+	randomizer Randomizer
+	jokes      []Joke
+	indexes    []int
 }
 
-func NewJSONJokes(data []byte) (*JSONJokes, error) {
+// NewJSONJokes falls back to crypto/rand when rng is nil.
+func NewJSONJokes(data []byte, rng Randomizer) (*JSONJokes, error) {
+	if rng == nil {
+		rng = CryptoRandomizer{}
+	}
+
 	var jokes []Joke
 	if err := json.Unmarshal(data, &jokes); err != nil {
 		return nil, err
 	}
 
 	log.Debugf("created new jokes generator with %v jokes", len(jokes))
-	generator := &JSONJokes{jokes: jokes}
+	generator := &JSONJokes{randomizer: rng, jokes: jokes}
 	generator.generateJokeIndexes()
 	return generator, nil
 }
 
 func (jj *JSONJokes) GetJoke() (Joke, error) {
 	var joke Joke
-	i, err := GetRandomInt(0, len(jj.indexes))
+	i, err := jj.randomizer.GetRandomInt(0, len(jj.indexes))
 	if err != nil {
 		log.Errorf("could not retrieve random int for picking a phrase: %v", err)
 		return joke, err
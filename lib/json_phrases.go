@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"text/template"
 
 	log "github.com/sirupsen/logrus"
@@ -15,29 +16,41 @@ type PhraseValues struct {
 }
 
 type JSONPhrases struct {
+	randomizer      Randomizer
 	templateIndexes []int
 	templates       []*template.Template
+	usesKiller      []bool // whether templates[i] credits a killer through {{.Killer}}
 }
 
-func NewJSONPhrases(data []byte) *JSONPhrases {
-	o := &JSONPhrases{}
+// NewJSONPhrases falls back to crypto/rand when rng is nil.
+func NewJSONPhrases(data []byte, rng Randomizer) *JSONPhrases {
+	if rng == nil {
+		rng = CryptoRandomizer{}
+	}
+
+	o := &JSONPhrases{randomizer: rng}
 	o.importJSON(data)
 	o.generateTemplateIndexes()
 	return o
 }
 
-func (jp *JSONPhrases) GetRandomPhrase(user string, mention string, living []string) string {
+// GetRandomPhrase also returns the index in living of the player credited with
+// the kill, or -1 when the phrase doesn't name a killer.
+func (jp *JSONPhrases) GetRandomPhrase(user string, mention string, living []string) (string, int) {
 	defaultPhrase := fmt.Sprintf("%v died of dysentery.", user)
 
 	killer := "another player"
-	if killerNum, err := GetRandomInt(0, len(living)); err == nil {
+	killerNum, err := jp.randomizer.GetRandomInt(0, len(living))
+	if err == nil {
 		killer = living[killerNum]
+	} else {
+		killerNum = -1
 	}
 
-	i, err := GetRandomInt(0, len(jp.templateIndexes))
+	i, err := jp.randomizer.GetRandomInt(0, len(jp.templateIndexes))
 	if err != nil {
 		log.Errorf("could not retrieve random int for picking a phrase: %v", err)
-		return defaultPhrase
+		return defaultPhrase, -1
 	}
 
 	dyingName := fmt.Sprintf("**%v**", user)
@@ -46,14 +59,19 @@ func (jp *JSONPhrases) GetRandomPhrase(user string, mention string, living []str
 	}
 
 	var result bytes.Buffer
-	tmpl := jp.templates[jp.templateIndexes[i]]
+	tmplIndex := jp.templateIndexes[i]
+	tmpl := jp.templates[tmplIndex]
 	vals := PhraseValues{
 		Killer: killer,
 		Dying:  dyingName,
 	}
 	if err := tmpl.Execute(&result, vals); err != nil {
 		log.Errorf("error executing template with vals: %v", err)
-		return defaultPhrase
+		return defaultPhrase, -1
+	}
+
+	if !jp.usesKiller[tmplIndex] {
+		killerNum = -1
 	}
 
 	if len(jp.templateIndexes) == 1 {
@@ -62,7 +80,7 @@ func (jp *JSONPhrases) GetRandomPhrase(user string, mention string, living []str
 		jp.templateIndexes = append(jp.templateIndexes[:i], jp.templateIndexes[i+1:]...)
 	}
 
-	return result.String()
+	return result.String(), killerNum
 }
 
 func (jp *JSONPhrases) PhraseCount() int {
@@ -86,6 +104,7 @@ func (jp *JSONPhrases) importJSON(data []byte) {
 		}
 
 		jp.templates = append(jp.templates, phraseTmpl)
+		jp.usesKiller = append(jp.usesKiller, strings.Contains(phrase, ".Killer"))
 	}
 
 	if len(jp.templates) == 0 {
@@ -17,7 +17,7 @@ func TestJSONPhrases_GetRandomPhrase_AllCompileAndExec(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	jp := NewJSONPhrases(data)
+	jp := NewJSONPhrases(data, nil)
 	for i, phrase := range jp.templates {
 		p := phrase
 		t.Run(fmt.Sprintf("Template %v", i), func(t *testing.T) {
@@ -40,7 +40,7 @@ func TestJSONPhrases_GetRandomPhrase_AllThenReset(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	jp := NewJSONPhrases(data)
+	jp := NewJSONPhrases(data, nil)
 	phraseCount := jp.PhraseCount()
 	seen := make(map[string]int, phraseCount)
 
@@ -49,7 +49,7 @@ func TestJSONPhrases_GetRandomPhrase_AllThenReset(t *testing.T) {
 	}
 
 	for i := 0; i < phraseCount; i++ {
-		str := jp.GetRandomPhrase("hey", "<@hey>", []string{"yo"})
+		str, _ := jp.GetRandomPhrase("hey", "<@hey>", []string{"yo"})
 		if _, ok := seen[str]; ok {
 			t.Fatalf("first round - dupe phrase before all have been used ('%v')", str)
 		}
@@ -62,7 +62,7 @@ func TestJSONPhrases_GetRandomPhrase_AllThenReset(t *testing.T) {
 	}
 
 	for i := 0; i < phraseCount; i++ {
-		str := jp.GetRandomPhrase("hey", "<@hey>", []string{"yo"})
+		str, _ := jp.GetRandomPhrase("hey", "<@hey>", []string{"yo"})
 		if seen[str] > 1 {
 			t.Fatalf("second round - phrase used again before all have been used ('%v')", str)
 		}
@@ -77,33 +77,64 @@ func TestJSONPhrases_GetRandomPhrase_AllThenReset(t *testing.T) {
 
 func TestJSONPhrases_GetRandomPhrase_SingleReplace(t *testing.T) {
 	data := []byte(`["{{.Dying}} killed by {{.Killer}}"]`)
-	jp := NewJSONPhrases(data)
+	jp := NewJSONPhrases(data, nil)
 
 	dying := &discordgo.User{Username: "dying user", ID: "123"}
 	dyingMention := fmt.Sprintf("<@%v>", dying.ID)
 	living := []string{"Player 1", "Player 2"}
 
-	actual := jp.GetRandomPhrase(dying.Username, dyingMention, living)
+	actual, killer := jp.GetRandomPhrase(dying.Username, dyingMention, living)
 	expected1 := fmt.Sprintf("%v killed by %v", dyingMention, living[0])
 	expected2 := fmt.Sprintf("%v killed by %v", dyingMention, living[1])
 	if actual != expected1 && actual != expected2 {
 		t.Errorf("expected '%v' to equal '%v' or '%v'", actual, expected1, expected2)
 	}
+
+	if killer < 0 || actual != fmt.Sprintf("%v killed by %v", dyingMention, living[killer]) {
+		t.Errorf("expected killer index %v to match the phrase '%v'", killer, actual)
+	}
+}
+
+func TestJSONPhrases_GetRandomPhrase_NoKillerSlot(t *testing.T) {
+	jp := NewJSONPhrases([]byte(`["{{.Dying}} tripped"]`), nil)
+
+	if _, killer := jp.GetRandomPhrase("dying user", "", []string{"Player 1"}); killer != -1 {
+		t.Errorf("expected no killer for a phrase without a killer slot but got %v", killer)
+	}
 }
 
 func TestGame_getRandomPhrase_MultiReplace(t *testing.T) {
 	phrase := "%s gave %s a poison flower, and %s said thanks while %s laughed"
 	data := []byte(fmt.Sprintf(`["%s"]`, fmt.Sprintf(phrase, "{{.Killer}}", "{{.Dying}}", "{{.Dying}}", "{{.Killer}}")))
-	jp := NewJSONPhrases(data)
+	jp := NewJSONPhrases(data, nil)
 
 	dying := &discordgo.User{Username: "dying user", ID: "123"}
 	dyingMention := fmt.Sprintf("<@%v>", dying.ID)
 	living := []string{"Player 1", "Player 2"}
 
-	actual := jp.GetRandomPhrase(dying.Username, dyingMention, living)
+	actual, _ := jp.GetRandomPhrase(dying.Username, dyingMention, living)
 	expected1 := fmt.Sprintf(phrase, living[0], dyingMention, dyingMention, living[0])
 	expected2 := fmt.Sprintf(phrase, living[1], dyingMention, dyingMention, living[1])
 	if actual != expected1 && actual != expected2 {
 		t.Errorf("expected '%v' to equal '%v' or '%v'", actual, expected1, expected2)
 	}
 }
+
+func TestJSONPhrases_GetRandomPhrase_SameSeedSamePhrases(t *testing.T) {
+	data, err := os.ReadFile(path.Join("..", settings.DataLocation, "phrases.en.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jp1 := NewJSONPhrases(data, NewSeededRandomizer(42))
+	jp2 := NewJSONPhrases(data, NewSeededRandomizer(42))
+	living := []string{"Player 1", "Player 2", "Player 3"}
+
+	for i := 0; i < jp1.PhraseCount()*2; i++ {
+		actual, _ := jp1.GetRandomPhrase("hey", "<@hey>", living)
+		expected, _ := jp2.GetRandomPhrase("hey", "<@hey>", living)
+		if actual != expected {
+			t.Fatalf("phrase %v differs for the same seed: '%v' != '%v'", i, actual, expected)
+		}
+	}
+}
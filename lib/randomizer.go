@@ -0,0 +1,65 @@
+package lib
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	mrand "math/rand/v2"
+	"sync"
+)
+
+// Randomizer is the source of every random decision made by the game engine.
+type Randomizer interface {
+	GetRandomInt(min, max int) (int, error)
+}
+
+// CryptoRandomizer draws from crypto/rand. Its results can't be replayed.
+type CryptoRandomizer struct{}
+
+func (CryptoRandomizer) GetRandomInt(min, max int) (int, error) {
+	return GetRandomInt(min, max)
+}
+
+// SeededRandomizer always produces the same sequence for the same seed, which
+// allows a game to be replayed exactly.
+type SeededRandomizer struct {
+	seed uint64
+	rng  *mrand.Rand
+	sync.Mutex
+}
+
+func NewSeededRandomizer(seed uint64) *SeededRandomizer {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], seed)
+
+	return &SeededRandomizer{
+		seed: seed,
+		rng:  mrand.New(mrand.NewChaCha8(sha256.Sum256(buf[:]))),
+	}
+}
+
+func (r *SeededRandomizer) Seed() uint64 {
+	return r.seed
+}
+
+func (r *SeededRandomizer) GetRandomInt(min, max int) (int, error) {
+	if max-min <= 0 {
+		return 0, fmt.Errorf("tried to get random int between [0, %v)", max-min)
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	return int(r.rng.Uint64N(uint64(max-min))) + min, nil
+}
+
+// NewSeed returns a fresh seed for a SeededRandomizer.
+func NewSeed() (uint64, error) {
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return 0, err
+	}
+
+	return binary.BigEndian.Uint64(buf[:]), nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"unicode/utf8"
 )
 
 var DoubleStruckMap = map[rune]rune{
@@ -97,3 +98,36 @@ func ToDoubleStruck(str string) string {
 
 	return strings.Map(toDS, str)
 }
+
+// Truncate shortens str to at most max bytes without splitting a rune, marking the cut with an ellipsis.
+func Truncate(str string, max int) string {
+	if len(str) <= max {
+		return str
+	}
+
+	const ellipsis = "…"
+	cut := max - len(ellipsis)
+	for cut > 0 && !utf8.RuneStart(str[cut]) {
+		cut--
+	}
+
+	return str[:cut] + ellipsis
+}
+
+func Ordinal(n int) string {
+	suffix := "th"
+	switch n % 100 {
+	case 11, 12, 13:
+	default:
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+
+	return fmt.Sprintf("%d%s", n, suffix)
+}
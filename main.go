@@ -10,6 +10,7 @@ import (
 	"github.com/deadloct/bitheroes-hg-bot/data"
 	"github.com/deadloct/bitheroes-hg-bot/game"
 	"github.com/deadloct/bitheroes-hg-bot/settings"
+	"github.com/deadloct/bitheroes-hg-bot/storage"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -29,12 +30,23 @@ func main() {
 		log.Panic(err)
 	}
 
-	commandManager := cmd.NewManager(data.PhrasesJSON, data.JokesJSON)
+	store, err := storage.Open(settings.GetenvStr("STORE"), settings.GetenvStr("STORE_PATH"))
+	if err != nil {
+		log.Panicf("error opening store: %v", err)
+	}
+
+	commandManager := cmd.NewManager(data.PhrasesJSON, data.JokesJSON, store)
+	if err := commandManager.MigrateLegacySettings(); err != nil {
+		log.Errorf("error migrating legacy guild settings: %v", err)
+	}
 
 	// Listen for server messages only
 	session.Identify.Intents = discordgo.IntentGuildMessages | discordgo.IntentGuildMessageReactions | discordgo.IntentMessageContent
 	session.AddHandler(commandManager.CommandHandler)
+	session.AddHandler(commandManager.ComponentHandler)
 	session.AddHandler(game.ManagerInstance(session).ReactionHandler)
+	session.AddHandler(game.ManagerInstance(session).ReactionRemoveHandler)
+	session.AddHandler(game.ManagerInstance(session).InteractionHandler)
 	if err := session.Open(); err != nil {
 		log.Panic(err)
 	}
@@ -45,6 +57,8 @@ func main() {
 	}
 	defer commandManager.DeregisterCommmands(session)
 
+	commandManager.RestoreClaims(session)
+
 	log.Info("Bot is now running. Press CTRL-C to exit.")
 	sc := make(chan os.Signal, 1)
 	signal.Notify(sc, syscall.SIGINT, syscall.SIGTERM, os.Interrupt)
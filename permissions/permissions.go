@@ -0,0 +1,191 @@
+// Package permissions decides who may run the bot's commands in a guild.
+package permissions
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/deadloct/bitheroes-hg-bot/storage"
+)
+
+type Action string
+
+const (
+	ActionStart  Action = "start"
+	ActionCancel Action = "cancel"
+	ActionClear  Action = "clear"
+)
+
+var Actions = []Action{ActionStart, ActionCancel, ActionClear}
+
+// Check is the context an action is checked in.
+type Check struct {
+	Member    *discordgo.Member // Permissions must be the member's permissions in the channel
+	StartedBy string            // user ID of the running game's starter, if any
+}
+
+// Allowed reports whether the member may run action. Administrators may run
+// anything, and the starter of a game may always cancel it. Otherwise a rule
+// configured for the action decides, falling back to the defaults:
+//
+//   - start: everybody
+//   - cancel: members with Manage Messages
+//   - clear: members with Manage Messages
+func Allowed(rules map[string]storage.PermissionRule, action Action, c Check) bool {
+	if c.Member == nil || c.Member.User == nil {
+		return false
+	}
+
+	if c.Member.Permissions&discordgo.PermissionAdministrator != 0 {
+		return true
+	}
+
+	if action == ActionCancel && c.StartedBy != "" && c.StartedBy == c.Member.User.ID {
+		return true
+	}
+
+	rule := rules[string(action)]
+	if rule.IsEmpty() {
+		switch action {
+		case ActionStart:
+			return true
+		default:
+			return c.Member.Permissions&discordgo.PermissionManageMessages != 0
+		}
+	}
+
+	if slices.Contains(rule.Users, c.Member.User.ID) {
+		return true
+	}
+
+	for _, role := range c.Member.Roles {
+		if slices.Contains(rule.Roles, role) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Describe lists who may run action, for denial messages and /hg-config permissions.
+func Describe(rules map[string]storage.PermissionRule, action Action) string {
+	who := []string{"administrators"}
+	if action == ActionCancel {
+		who = append(who, "the tribute who started the game")
+	}
+
+	rule := rules[string(action)]
+	if rule.IsEmpty() {
+		switch action {
+		case ActionStart:
+			return "everybody"
+		default:
+			who = append(who, "members with Manage Messages")
+		}
+	}
+
+	for _, role := range rule.Roles {
+		who = append(who, fmt.Sprintf("<@&%v>", role))
+	}
+
+	for _, user := range rule.Users {
+		who = append(who, fmt.Sprintf("<@%v>", user))
+	}
+
+	return strings.Join(who, ", ")
+}
+
+// Grant allows a role or user to run action, returning the updated rules.
+func Grant(rules map[string]storage.PermissionRule, action Action, roleID, userID string) map[string]storage.PermissionRule {
+	if rules == nil {
+		rules = make(map[string]storage.PermissionRule)
+	}
+
+	rule := rules[string(action)]
+	if roleID != "" && !slices.Contains(rule.Roles, roleID) {
+		rule.Roles = append(rule.Roles, roleID)
+	}
+
+	if userID != "" && !slices.Contains(rule.Users, userID) {
+		rule.Users = append(rule.Users, userID)
+	}
+
+	rules[string(action)] = rule
+	return rules
+}
+
+// Revoke removes a role or user from action's rule. Removing the last one
+// restores the action's default.
+func Revoke(rules map[string]storage.PermissionRule, action Action, roleID, userID string) map[string]storage.PermissionRule {
+	rule, ok := rules[string(action)]
+	if !ok {
+		return rules
+	}
+
+	rule.Roles = slices.DeleteFunc(rule.Roles, func(id string) bool { return id == roleID })
+	rule.Users = slices.DeleteFunc(rule.Users, func(id string) bool { return id == userID })
+
+	if rule.IsEmpty() {
+		delete(rules, string(action))
+	} else {
+		rules[string(action)] = rule
+	}
+
+	return rules
+}
+
+func IsAction(str string) bool {
+	return slices.Contains(Actions, Action(str))
+}
+
+var (
+	ErrRoleNotFound  = errors.New("the role no longer exists")
+	ErrRoleManaged   = errors.New("the role is managed by an integration")
+	ErrNoManageRoles = errors.New("the bot is missing the Manage Roles permission")
+	ErrRoleAboveBot  = errors.New("the role is not below the bot's highest role")
+)
+
+// CanManageRole reports why member, usually the bot, can't give roleID to
+// other members, or nil if it can. Guild owners are not handled since bots
+// never own guilds.
+func CanManageRole(guildID string, roles []*discordgo.Role, member *discordgo.Member, roleID string) error {
+	byID := make(map[string]*discordgo.Role, len(roles))
+	for _, r := range roles {
+		byID[r.ID] = r
+	}
+
+	target, ok := byID[roleID]
+	if !ok {
+		return ErrRoleNotFound
+	}
+
+	if target.Managed {
+		return ErrRoleManaged
+	}
+
+	// Every member has the @everyone role, whose ID is the guild's.
+	var perms int64
+	var highest int
+	for _, id := range append([]string{guildID}, member.Roles...) {
+		r, ok := byID[id]
+		if !ok {
+			continue
+		}
+
+		perms |= r.Permissions
+		highest = max(highest, r.Position)
+	}
+
+	if perms&(discordgo.PermissionManageRoles|discordgo.PermissionAdministrator) == 0 {
+		return ErrNoManageRoles
+	}
+
+	if target.Position >= highest {
+		return ErrRoleAboveBot
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package permissions
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/deadloct/bitheroes-hg-bot/storage"
+)
+
+func testMember(id string, perms int64, roles ...string) *discordgo.Member {
+	return &discordgo.Member{User: &discordgo.User{ID: id}, Permissions: perms, Roles: roles}
+}
+
+func TestAllowed_Defaults(t *testing.T) {
+	tests := map[string]struct {
+		action   Action
+		check    Check
+		expected bool
+	}{
+		"anyone can start":            {ActionStart, Check{Member: testMember("1", 0)}, true},
+		"member cannot cancel":        {ActionCancel, Check{Member: testMember("1", 0), StartedBy: "2"}, false},
+		"starter can cancel":          {ActionCancel, Check{Member: testMember("2", 0), StartedBy: "2"}, true},
+		"moderator can cancel":        {ActionCancel, Check{Member: testMember("1", discordgo.PermissionManageMessages), StartedBy: "2"}, true},
+		"member cannot clear":         {ActionClear, Check{Member: testMember("1", 0)}, false},
+		"moderator can clear":         {ActionClear, Check{Member: testMember("1", discordgo.PermissionManageMessages)}, true},
+		"administrator can clear":     {ActionClear, Check{Member: testMember("1", discordgo.PermissionAdministrator)}, true},
+		"missing member is denied":    {ActionStart, Check{}, false},
+		"empty starter is not a user": {ActionCancel, Check{Member: testMember("", 0)}, false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if actual := Allowed(nil, tc.action, tc.check); actual != tc.expected {
+				t.Errorf("expected %v but got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestAllowed_Rules(t *testing.T) {
+	rules := Grant(nil, ActionStart, "hosts", "")
+	rules = Grant(rules, ActionClear, "", "3")
+
+	if Allowed(rules, ActionStart, Check{Member: testMember("1", 0)}) {
+		t.Error("expected members without the hosts role to be denied starting")
+	}
+
+	if !Allowed(rules, ActionStart, Check{Member: testMember("1", 0, "hosts")}) {
+		t.Error("expected members with the hosts role to be allowed to start")
+	}
+
+	if Allowed(rules, ActionClear, Check{Member: testMember("1", discordgo.PermissionManageMessages)}) {
+		t.Error("expected a clear rule to replace the Manage Messages default")
+	}
+
+	if !Allowed(rules, ActionClear, Check{Member: testMember("3", 0)}) {
+		t.Error("expected user 3 to be allowed to clear")
+	}
+
+	rules = Revoke(rules, ActionClear, "", "3")
+	if _, ok := rules[string(ActionClear)]; ok {
+		t.Errorf("expected revoking the last user to restore the default but got %+v", rules)
+	}
+
+	if !Allowed(rules, ActionClear, Check{Member: testMember("1", discordgo.PermissionManageMessages)}) {
+		t.Error("expected Manage Messages to be allowed to clear again")
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	rules := map[string]storage.PermissionRule{string(ActionCancel): {Roles: []string{"mods"}}}
+
+	if actual := Describe(rules, ActionCancel); actual != "administrators, the tribute who started the game, <@&mods>" {
+		t.Errorf("unexpected description %q", actual)
+	}
+
+	if actual := Describe(nil, ActionStart); actual != "everybody" {
+		t.Errorf("unexpected description %q", actual)
+	}
+}
+
+func TestCanManageRole(t *testing.T) {
+	roles := []*discordgo.Role{
+		{ID: "guild", Position: 0},
+		{ID: "ping", Position: 1},
+		{ID: "bot", Position: 2, Permissions: discordgo.PermissionManageRoles},
+		{ID: "mods", Position: 3},
+		{ID: "integration", Position: 1, Managed: true},
+	}
+
+	bot := &discordgo.Member{User: &discordgo.User{ID: "bot"}, Roles: []string{"bot"}}
+	tests := map[string]struct {
+		member   *discordgo.Member
+		role     string
+		expected error
+	}{
+		"below the bot":      {bot, "ping", nil},
+		"above the bot":      {bot, "mods", ErrRoleAboveBot},
+		"bot's own role":     {bot, "bot", ErrRoleAboveBot},
+		"managed role":       {bot, "integration", ErrRoleManaged},
+		"deleted role":       {bot, "gone", ErrRoleNotFound},
+		"missing permission": {&discordgo.Member{User: &discordgo.User{ID: "bot"}, Roles: []string{"mods"}}, "ping", ErrNoManageRoles},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if actual := CanManageRole("guild", roles, tc.member, tc.role); actual != tc.expected {
+				t.Errorf("expected %v but got %v", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package settings
 
-import "fmt"
+import (
+	"fmt"
+	"regexp"
+)
 
 type EmojiKey string
 
@@ -30,7 +33,12 @@ var (
 	EmojiEffie       EmojiKey = "Effie"
 	EmojiCaesar      EmojiKey = "Caesar"
 
+	// EmojiKeys lists every emoji a guild can replace.
+	EmojiKeys = []EmojiKey{EmojiParticipant, EmojiClone, EmojiPresSnow, EmojiEffie, EmojiCaesar}
+
 	emojis map[EmojiKey]EmojiInfo
+
+	customEmojiRegex = regexp.MustCompile(`^<(a?):(\w+):(\d+)>$`)
 )
 
 func LoadEmojis() {
@@ -75,3 +83,13 @@ func GetEmoji(key EmojiKey) EmojiInfo {
 
 	return v
 }
+
+// ParseEmoji reads a custom emoji as Discord sends it in a message, like <:name:id> or <a:name:id>.
+func ParseEmoji(str string) (EmojiInfo, bool) {
+	m := customEmojiRegex.FindStringSubmatch(str)
+	if m == nil {
+		return EmojiInfo{}, false
+	}
+
+	return EmojiInfo{Name: m[2], ID: m[3], Animated: m[1] == "a"}, true
+}
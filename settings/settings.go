@@ -1,6 +1,10 @@
 package settings
 
 import (
+	"io/fs"
+	"slices"
+	"sort"
+	"strings"
 	"text/template"
 	"time"
 
@@ -19,14 +23,32 @@ const (
 	MinimumClone = 1
 	MaximumClone = 20
 
-	DefaultDayDelay    = 5 * time.Second
-	DefaultVictorCount = 1
-	MinimumVictorCount = 0
+	DefaultDayDelay        = 5 * time.Second
+	MinimumDayDelaySeconds = 0.5
+	MaximumDayDelaySeconds = 120.0
+	DefaultVictorCount     = 1
+	MinimumVictorCount     = 0
 
-	JokeInterval = 10 * time.Second
+	JokeInterval        = 10 * time.Second
+	IntroUpdateInterval = 30 * time.Second // how often the intro's tribute count is refreshed
+	DefaultReminder     = 5 * time.Minute  // before entries close
+	MaximumReminders    = 5
+
+	MaximumPrizes       = 10
+	MinimumClaimMinutes = 1
+	MaximumClaimMinutes = 7 * 24 * 60 // 1 week
+	HistoryPageSize     = 10
+	ParticipantsPerPage = 25
+	LeaderboardSize     = 10
+	LeaderboardMinGames = 3 // for average placement and win rate
+
+	StandingsPlaces = 10 // places shown in the final standings, full results are in /hg-history
 
 	MaxMsgLen            = 1500
+	DiscordMaxMsgLen     = 2000
+	MaxEmbedLen          = 4096
 	DiscordMaxMessages   = 100
+	DiscordMaxReactions  = 100 // users per reactions request
 	DiscordMaxBulkDelete = 100
 
 	WhiteSpaceChar = "\u200d"
@@ -35,40 +57,139 @@ const (
 	DayEmoji     = "skull_crossbones"
 
 	MaxQuietDays = 3
+
+	DefaultFinishDays = 5 // for the finish-in pacing
+	MaximumFinishDays = 30
+
+	MinimumDayDelay       = 2 * time.Second                      // shortest day a target duration plans for
+	MaximumDayDelay       = MaximumDayDelaySeconds * time.Second // longest
+	MinimumTargetDuration = 1                                    // minutes
+	MaximumTargetDuration = 240
 )
 
+const DefaultLocale = "en"
+
+// SpeedPreset is a named day delay for /hg-start.
+type SpeedPreset struct {
+	Name     string
+	DayDelay time.Duration
+}
+
+var SpeedPresets = []SpeedPreset{
+	{Name: "instant", DayDelay: 500 * time.Millisecond},
+	{Name: "fast", DayDelay: 2 * time.Second},
+	{Name: "normal", DayDelay: DefaultDayDelay},
+	{Name: "dramatic", DayDelay: 15 * time.Second},
+}
+
+// SpeedDayDelay returns the day delay of the preset called name.
+func SpeedDayDelay(name string) (time.Duration, bool) {
+	for _, p := range SpeedPresets {
+		if p.Name == name {
+			return p.DayDelay, true
+		}
+	}
+
+	return 0, false
+}
+
 var (
-	Intro *template.Template
-	Help  string // not currently a template
+	intros  map[string]*template.Template // maps locale to intro template
+	helps   map[string]string             // maps locale to help text, not currently a template
+	Locales []string                      // every locale with an intro, sorted
 )
 
 type IntroValues struct {
-	Delay       time.Duration
-	EntryEmoji  string
-	EffieEmoji  string
-	CloneEmoji  string
-	Clone       int
-	MinimumTier int
-	Sponsor     string
-	VictorCount int
+	Commitment   string
+	Deadline     int64 // Unix time entries close
+	Delay        time.Duration
+	Eligibility  []string // entry rules besides the minimum tier
+	EntryButtons bool
+	EntryEmoji   string
+	EffieEmoji   string
+	CloneEmoji   string
+	Clone        int
+	MinimumTier  int
+	TierEnforced bool     // whether guild roles prove the tier
+	Prizes       []string // already labeled with their placement
+	Sponsor      string
+	VictorCount  int
+	Weights      []string // survival weights that differ from the usual odds
+	Pacing       string   // empty for the classic pacing
+	Status       string   // tribute count or "Entries closed", below the rules
 }
 
 func ImportData() {
-	importIntro()
-	importHelp()
+	importIntros()
+	importHelps()
 }
 
-func importIntro() {
-	var err error
-	Intro, err = template.New("intro-template").Parse(data.IntroTemplate)
-	if err != nil {
-		log.Panicf("unable to parse intro template '%v': %v", data.IntroTemplate, err)
+// Intro returns the intro template for locale, falling back to DefaultLocale.
+func Intro(locale string) *template.Template {
+	if t, ok := intros[locale]; ok {
+		return t
+	}
+
+	return intros[DefaultLocale]
+}
+
+// Help returns the help text for locale, falling back to DefaultLocale.
+func Help(locale string) string {
+	if h, ok := helps[locale]; ok {
+		return h
 	}
 
-	log.Info("imported intro template")
+	return helps[DefaultLocale]
 }
 
-func importHelp() {
-	Help = data.HelpTemplate
-	log.Info("imported help file")
+func IsLocale(locale string) bool {
+	return slices.Contains(Locales, locale)
+}
+
+func importIntros() {
+	intros = make(map[string]*template.Template)
+	Locales = nil
+
+	for locale, text := range readTemplates("intro") {
+		t, err := template.New("intro-template-" + locale).Parse(text)
+		if err != nil {
+			log.Panicf("unable to parse intro template '%v': %v", text, err)
+		}
+
+		intros[locale] = t
+		Locales = append(Locales, locale)
+	}
+
+	if _, ok := intros[DefaultLocale]; !ok {
+		log.Panicf("missing intro template for default locale %v", DefaultLocale)
+	}
+
+	sort.Strings(Locales)
+	log.Infof("imported intro templates for locales %v", Locales)
+}
+
+func importHelps() {
+	helps = readTemplates("help")
+	log.Infof("imported %v help files", len(helps))
+}
+
+// readTemplates maps locale to the contents of every <kind>.<locale>.template.
+func readTemplates(kind string) map[string]string {
+	files, err := fs.Glob(data.Templates, kind+".*.template")
+	if err != nil {
+		log.Panicf("unable to list %v templates: %v", kind, err)
+	}
+
+	result := make(map[string]string)
+	for _, file := range files {
+		b, err := fs.ReadFile(data.Templates, file)
+		if err != nil {
+			log.Panicf("unable to read %v: %v", file, err)
+		}
+
+		locale := strings.TrimSuffix(strings.TrimPrefix(file, kind+"."), ".template")
+		result[locale] = string(b)
+	}
+
+	return result
 }
@@ -0,0 +1,57 @@
+package settings
+
+import "testing"
+
+func TestImportData_Locales(t *testing.T) {
+	ImportData()
+
+	if !IsLocale(DefaultLocale) {
+		t.Fatalf("expected the default locale in %v", Locales)
+	}
+
+	if Intro("xx") != Intro(DefaultLocale) || Help("xx") != Help(DefaultLocale) {
+		t.Error("expected unknown locales to fall back to the default")
+	}
+
+	if Help(DefaultLocale) == "" {
+		t.Error("expected help text for the default locale")
+	}
+}
+
+func TestParseEmoji(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		expected EmojiInfo
+		ok       bool
+	}{
+		"static":   {"<:hungergames:123>", EmojiInfo{Name: "hungergames", ID: "123"}, true},
+		"animated": {"<a:agentsmith:456>", EmojiInfo{Name: "agentsmith", ID: "456", Animated: true}, true},
+		"unicode":  {"🔥", EmojiInfo{}, false},
+		"name":     {":hungergames:", EmojiInfo{}, false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, ok := ParseEmoji(tc.input)
+			if ok != tc.ok || actual != tc.expected {
+				t.Errorf("expected %+v, %v but got %+v, %v", tc.expected, tc.ok, actual, ok)
+			}
+		})
+	}
+}
+
+func TestSpeedDayDelay(t *testing.T) {
+	if delay, ok := SpeedDayDelay("normal"); !ok || delay != DefaultDayDelay {
+		t.Errorf("expected the normal speed to use the default day delay but got %v", delay)
+	}
+
+	for i := 1; i < len(SpeedPresets); i++ {
+		if SpeedPresets[i].DayDelay <= SpeedPresets[i-1].DayDelay {
+			t.Errorf("expected %v to be slower than %v", SpeedPresets[i].Name, SpeedPresets[i-1].Name)
+		}
+	}
+
+	if _, ok := SpeedDayDelay("ludicrous"); ok {
+		t.Error("expected an unknown speed to be rejected")
+	}
+}
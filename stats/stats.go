@@ -0,0 +1,179 @@
+package stats
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/deadloct/bitheroes-hg-bot/storage"
+)
+
+type SortKey string
+
+const (
+	SortWins      SortKey = "wins"
+	SortGames     SortKey = "games"
+	SortKills     SortKey = "kills"
+	SortPlacement SortKey = "placement"
+	SortWinRate   SortKey = "win-rate"
+)
+
+var SortKeys = []SortKey{SortWins, SortGames, SortKills, SortPlacement, SortWinRate}
+
+// PlayerStats are a user's totals across finished games. Clones count toward the user they were cloned from.
+type PlayerStats struct {
+	UserID string
+	Name   string
+	Games  int
+	Wins   int
+	Kills  int
+
+	placementTotal int
+}
+
+func (s *PlayerStats) AveragePlacement() float64 {
+	if s.Games == 0 {
+		return 0
+	}
+
+	return float64(s.placementTotal) / float64(s.Games)
+}
+
+func (s *PlayerStats) WinRate() float64 {
+	if s.Games == 0 {
+		return 0
+	}
+
+	return float64(s.Wins) / float64(s.Games)
+}
+
+// Aggregate expects records newest first, as returned by storage.Store, so that
+// each user keeps their most recent display name.
+func Aggregate(recs []*storage.GameRecord) map[string]*PlayerStats {
+	result := make(map[string]*PlayerStats)
+	get := func(p storage.PlayerRecord) *PlayerStats {
+		s, ok := result[p.UserID]
+		if !ok {
+			s = &PlayerStats{UserID: p.UserID, Name: p.Name}
+			result[p.UserID] = s
+		}
+
+		return s
+	}
+
+	for _, rec := range recs {
+		if rec.State != storage.GameFinished {
+			continue
+		}
+
+		for _, p := range rec.Entrants {
+			get(p).Games++
+		}
+
+		for userID, place := range Placements(rec) {
+			if s, ok := result[userID]; ok {
+				s.placementTotal += place
+			}
+		}
+
+		won := make(map[string]struct{})
+		for _, p := range rec.CurrentVictors() {
+			if _, ok := won[p.UserID]; !ok {
+				won[p.UserID] = struct{}{}
+				get(p).Wins++
+			}
+		}
+
+		for _, e := range rec.Eliminations {
+			if e.Killer != nil {
+				get(*e.Killer).Kills++
+			}
+		}
+	}
+
+	return result
+}
+
+// Placements maps each user to their best finish in a game. Victors share 1st,
+// and tributes eliminated on the same day share a place below everyone who
+// outlasted them. A victor disqualified with /hg-reroll places below every
+// tribute and their replacement shares 1st.
+func Placements(rec *storage.GameRecord) map[string]int {
+	result := make(map[string]int)
+	set := func(userID string, place int) {
+		if best, ok := result[userID]; !ok || place < best {
+			result[userID] = place
+		}
+	}
+
+	for _, p := range rec.CurrentVictors() {
+		set(p.UserID, 1)
+	}
+
+	ranked := len(rec.Victors)
+	for i := len(rec.Eliminations) - 1; i >= 0; {
+		day := rec.Eliminations[i].Day
+		j := i
+		for j >= 0 && rec.Eliminations[j].Day == day {
+			set(rec.Eliminations[j].Player.UserID, ranked+1)
+			j--
+		}
+
+		ranked += i - j
+		i = j
+	}
+
+	for _, rr := range rec.Rerolls {
+		if !slices.ContainsFunc(rec.CurrentVictors(), func(p storage.PlayerRecord) bool { return p.UserID == rr.Revoked.UserID }) {
+			result[rr.Revoked.UserID] = len(rec.Victors) + len(rec.Eliminations) + 1
+		}
+	}
+
+	return result
+}
+
+// Leaderboard sorts every player by key, best first. Ratio based keys skip
+// players with fewer than minGames games so one lucky win doesn't top the board.
+func Leaderboard(all map[string]*PlayerStats, key SortKey, minGames int) []*PlayerStats {
+	var board []*PlayerStats
+	for _, s := range all {
+		if (key == SortPlacement || key == SortWinRate) && s.Games < minGames {
+			continue
+		}
+
+		board = append(board, s)
+	}
+
+	sort.Slice(board, func(i, j int) bool {
+		a, b := board[i], board[j]
+		switch key {
+		case SortGames:
+			if a.Games != b.Games {
+				return a.Games > b.Games
+			}
+		case SortKills:
+			if a.Kills != b.Kills {
+				return a.Kills > b.Kills
+			}
+		case SortPlacement:
+			if a.AveragePlacement() != b.AveragePlacement() {
+				return a.AveragePlacement() < b.AveragePlacement()
+			}
+		case SortWinRate:
+			if a.WinRate() != b.WinRate() {
+				return a.WinRate() > b.WinRate()
+			}
+		}
+
+		if a.Wins != b.Wins {
+			return a.Wins > b.Wins
+		}
+
+		if a.Games != b.Games {
+			return a.Games < b.Games
+		}
+
+		return a.UserID < b.UserID
+	})
+
+	return board
+}
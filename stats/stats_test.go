@@ -0,0 +1,103 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/deadloct/bitheroes-hg-bot/storage"
+)
+
+func player(id string) storage.PlayerRecord {
+	return storage.PlayerRecord{UserID: id, Name: "name-" + id}
+}
+
+func elimination(day int, id string, killer string) storage.EliminationRecord {
+	e := storage.EliminationRecord{Day: day, Player: player(id)}
+	if killer != "" {
+		k := player(killer)
+		e.Killer = &k
+	}
+
+	return e
+}
+
+func testRecords() []*storage.GameRecord {
+	return []*storage.GameRecord{
+		{
+			State:    storage.GameFinished,
+			Entrants: []storage.PlayerRecord{player("a"), player("b"), player("c"), player("d")},
+			Eliminations: []storage.EliminationRecord{
+				elimination(0, "d", "a"),
+				elimination(0, "c", "a"),
+				elimination(1, "b", ""),
+			},
+			Victors: []storage.PlayerRecord{player("a")},
+		},
+		{
+			State:    storage.GameFinished,
+			Entrants: []storage.PlayerRecord{player("a"), player("b")},
+			// b was cloned, so both victors are b and the clone's kill counts for b
+			Eliminations: []storage.EliminationRecord{elimination(0, "a", "b")},
+			Victors:      []storage.PlayerRecord{player("b"), player("b")},
+		},
+		{
+			State:    storage.GameCancelled,
+			Entrants: []storage.PlayerRecord{player("a"), player("b")},
+		},
+	}
+}
+
+func TestPlacements_TiesOnSameDay(t *testing.T) {
+	places := Placements(testRecords()[0])
+	expected := map[string]int{"a": 1, "b": 2, "c": 3, "d": 3}
+	for id, place := range expected {
+		if places[id] != place {
+			t.Errorf("expected %v to place %v but got %v", id, place, places[id])
+		}
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	all := Aggregate(testRecords())
+
+	a, b := all["a"], all["b"]
+	if a.Games != 2 || a.Wins != 1 || a.Kills != 2 || a.AveragePlacement() != 2 {
+		t.Errorf("unexpected stats for a: %+v avg %v", a, a.AveragePlacement())
+	}
+
+	if b.Games != 2 || b.Wins != 1 || b.Kills != 1 || b.AveragePlacement() != 1.5 {
+		t.Errorf("unexpected stats for b: %+v avg %v", b, b.AveragePlacement())
+	}
+}
+
+func TestAggregate_Rerolls(t *testing.T) {
+	recs := testRecords()
+	c := player("c")
+	recs[0].Rerolls = []storage.RerollRecord{{Revoked: player("a"), Replacement: &c}}
+
+	all := Aggregate(recs)
+	if all["a"].Wins != 0 || all["c"].Wins != 1 {
+		t.Errorf("expected the win to pass from a to c but got a %+v and c %+v", all["a"], all["c"])
+	}
+
+	if places := Placements(recs[0]); places["c"] != 1 || places["b"] != 2 || places["a"] != 5 {
+		t.Errorf("expected c to share 1st and a to place below every tribute but got %v", places)
+	}
+
+	if avg := all["a"].AveragePlacement(); avg != 4 {
+		t.Errorf("expected the disqualification to count against a's average placement but got %v", avg)
+	}
+}
+
+func TestLeaderboard(t *testing.T) {
+	all := Aggregate(testRecords())
+
+	board := Leaderboard(all, SortKills, 0)
+	if board[0].UserID != "a" {
+		t.Errorf("expected a to lead kills but got %v", board[0].UserID)
+	}
+
+	board = Leaderboard(all, SortPlacement, 2)
+	if len(board) != 2 {
+		t.Errorf("expected only players with 2 games on the placement board but got %v", len(board))
+	}
+}
@@ -0,0 +1,144 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// FileStore keeps one JSON file per game under <dir>/<guild ID>/games and the
+// guild's settings in <dir>/<guild ID>/settings.json. Every record is loaded
+// into memory when the store is opened and written through on save.
+type FileStore struct {
+	dir string
+	mem *MemoryStore
+
+	// Saves of the same game can race, like a prize claim and an expiring
+	// claim, so writes are serialized to keep them from interleaving.
+	sync.Mutex
+}
+
+func NewFileStore(dir string) (*FileStore, error) {
+	s := &FileStore{dir: dir, mem: NewMemoryStore()}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *FileStore) SaveGame(rec *GameRecord) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if err := s.writeJSON(filepath.Join(s.dir, rec.GuildID, "games", rec.ID+".json"), rec); err != nil {
+		return err
+	}
+
+	return s.mem.SaveGame(rec)
+}
+
+func (s *FileStore) GetGame(guildID, gameID string) (*GameRecord, error) {
+	return s.mem.GetGame(guildID, gameID)
+}
+
+func (s *FileStore) ListGames(guildID string, offset, limit int) ([]*GameRecord, int, error) {
+	return s.mem.ListGames(guildID, offset, limit)
+}
+
+func (s *FileStore) GetGuildSettings(guildID string) (*GuildSettings, error) {
+	return s.mem.GetGuildSettings(guildID)
+}
+
+func (s *FileStore) SaveGuildSettings(settings *GuildSettings) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if err := s.writeJSON(filepath.Join(s.dir, settings.GuildID, "settings.json"), settings); err != nil {
+		return err
+	}
+
+	return s.mem.SaveGuildSettings(settings)
+}
+
+func (s *FileStore) load() error {
+	games, err := filepath.Glob(filepath.Join(s.dir, "*", "games", "*.json"))
+	if err != nil {
+		return err
+	}
+
+	var loaded int
+	for _, file := range games {
+		var rec GameRecord
+		if err := s.readJSON(file, &rec); err != nil {
+			log.Errorf("skipping unreadable game record %v: %v", file, err)
+			continue
+		}
+
+		s.mem.SaveGame(&rec)
+		loaded++
+	}
+
+	log.Infof("loaded %v game records from %v", loaded, s.dir)
+
+	guilds, err := filepath.Glob(filepath.Join(s.dir, "*", "settings.json"))
+	if err != nil {
+		return err
+	}
+
+	loaded = 0
+	for _, file := range guilds {
+		var gs GuildSettings
+		if err := s.readJSON(file, &gs); err != nil {
+			log.Errorf("skipping unreadable guild settings %v: %v", file, err)
+			continue
+		}
+
+		s.mem.SaveGuildSettings(&gs)
+		loaded++
+	}
+
+	log.Infof("loaded settings for %v guilds from %v", loaded, s.dir)
+	return nil
+}
+
+func (s *FileStore) readJSON(file string, v any) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// writeJSON writes to a temporary file first so a crash never leaves a partial
+// record behind. It must be called with the lock held.
+func (s *FileStore) writeJSON(file string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return err
+	}
+
+	tmp := file + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, file)
+}
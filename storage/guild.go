@@ -0,0 +1,102 @@
+package storage
+
+import "time"
+
+// GuildSettings holds everything a guild has configured. Guilds that never
+// changed a setting have no record, and zero values use the bot's defaults.
+type GuildSettings struct {
+	GuildID          string                    `json:"guild_id"`
+	NotificationRole string                    `json:"notification_role,omitempty"` // pinged when a game starts
+	StartDelay       time.Duration             `json:"start_delay,omitempty"`
+	VictorCount      int                       `json:"victor_count,omitempty"`
+	Emojis           map[string]EmojiRecord    `json:"emojis,omitempty"` // maps settings.EmojiKey to the guild's emoji
+	Locale           string                    `json:"locale,omitempty"`
+	TierRoles        map[string]int            `json:"tier_roles,omitempty"`  // maps role ID to the Bit Heroes tier it proves
+	Permissions      map[string]PermissionRule `json:"permissions,omitempty"` // maps command action to who may run it
+	Winners          *WinnerPolicy             `json:"winners,omitempty"`
+	Weights          *SurvivalWeights          `json:"weights,omitempty"`
+}
+
+type EmojiRecord struct {
+	Name     string `json:"name"`
+	ID       string `json:"id"`
+	Animated bool   `json:"animated,omitempty"`
+}
+
+// WinnerPolicy keeps recent victors out of new games or lowers their odds.
+type WinnerPolicy struct {
+	CooldownDays    int `json:"cooldown_days,omitempty"`
+	CooldownGames   int `json:"cooldown_games,omitempty"`
+	HandicapDays    int `json:"handicap_days,omitempty"`
+	HandicapPercent int `json:"handicap_percent,omitempty"` // survival odds of handicapped victors, 1-99
+}
+
+// SurvivalWeights change the odds of surviving each day, in percent of the
+// usual odds. Zero values are turned off.
+type SurvivalWeights struct {
+	BoosterPercent int            `json:"booster_percent,omitempty"`
+	PityLosses     int            `json:"pity_losses,omitempty"` // losing streak needed for PityPercent
+	PityPercent    int            `json:"pity_percent,omitempty"`
+	Roles          map[string]int `json:"roles,omitempty"` // maps role ID to percent
+}
+
+func (w SurvivalWeights) IsEmpty() bool {
+	return w.BoosterPercent == 0 && w.PityLosses == 0 && w.PityPercent == 0 && len(w.Roles) == 0
+}
+
+// PermissionRule lists the roles and users allowed to run an action. An empty
+// rule falls back to the action's default.
+type PermissionRule struct {
+	Roles []string `json:"roles,omitempty"`
+	Users []string `json:"users,omitempty"`
+}
+
+func (r PermissionRule) IsEmpty() bool {
+	return len(r.Roles) == 0 && len(r.Users) == 0
+}
+
+// Clone returns a deep copy so callers can change settings without racing the store.
+func (s *GuildSettings) Clone() *GuildSettings {
+	c := *s
+	if s.Emojis != nil {
+		c.Emojis = make(map[string]EmojiRecord, len(s.Emojis))
+		for key, emoji := range s.Emojis {
+			c.Emojis[key] = emoji
+		}
+	}
+
+	if s.TierRoles != nil {
+		c.TierRoles = make(map[string]int, len(s.TierRoles))
+		for role, tier := range s.TierRoles {
+			c.TierRoles[role] = tier
+		}
+	}
+
+	if s.Permissions != nil {
+		c.Permissions = make(map[string]PermissionRule, len(s.Permissions))
+		for action, rule := range s.Permissions {
+			c.Permissions[action] = PermissionRule{
+				Roles: append([]string(nil), rule.Roles...),
+				Users: append([]string(nil), rule.Users...),
+			}
+		}
+	}
+
+	if s.Winners != nil {
+		w := *s.Winners
+		c.Winners = &w
+	}
+
+	if s.Weights != nil {
+		w := *s.Weights
+		if s.Weights.Roles != nil {
+			w.Roles = make(map[string]int, len(s.Weights.Roles))
+			for role, pct := range s.Weights.Roles {
+				w.Roles[role] = pct
+			}
+		}
+		c.Weights = &w
+	}
+
+	return &c
+}
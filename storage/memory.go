@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"sort"
+	"sync"
+)
+
+type MemoryStore struct {
+	games  map[string][]*GameRecord  // maps guild ID to games, newest first
+	guilds map[string]*GuildSettings // maps guild ID to settings
+	sync.Mutex
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		games:  make(map[string][]*GameRecord),
+		guilds: make(map[string]*GuildSettings),
+	}
+}
+
+func (s *MemoryStore) SaveGame(rec *GameRecord) error {
+	s.Lock()
+	defer s.Unlock()
+
+	rec = rec.Clone()
+	games := s.games[rec.GuildID]
+	for i, existing := range games {
+		if existing.ID == rec.ID {
+			games[i] = rec
+			return nil
+		}
+	}
+
+	games = append(games, rec)
+	sort.SliceStable(games, func(i, j int) bool {
+		return games[i].CreatedAt.After(games[j].CreatedAt)
+	})
+	s.games[rec.GuildID] = games
+
+	return nil
+}
+
+func (s *MemoryStore) GetGame(guildID, gameID string) (*GameRecord, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	for _, rec := range s.games[guildID] {
+		if rec.ID == gameID {
+			return rec.Clone(), nil
+		}
+	}
+
+	return nil, ErrNotFound
+}
+
+func (s *MemoryStore) ListGames(guildID string, offset, limit int) ([]*GameRecord, int, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	games := s.games[guildID]
+	total := len(games)
+	if offset >= total {
+		return nil, total, nil
+	}
+
+	end := total
+	if limit > 0 && offset+limit < total {
+		end = offset + limit
+	}
+
+	var page []*GameRecord
+	for _, rec := range games[offset:end] {
+		page = append(page, rec.Clone())
+	}
+
+	return page, total, nil
+}
+
+func (s *MemoryStore) GetGuildSettings(guildID string) (*GuildSettings, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	gs, ok := s.guilds[guildID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return gs.Clone(), nil
+}
+
+func (s *MemoryStore) SaveGuildSettings(settings *GuildSettings) error {
+	s.Lock()
+	defer s.Unlock()
+
+	s.guilds[settings.GuildID] = settings.Clone()
+	return nil
+}
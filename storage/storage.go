@@ -0,0 +1,168 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+)
+
+const (
+	KindMemory = "memory"
+	KindFile   = "file"
+)
+
+var ErrNotFound = errors.New("not found")
+
+// Store persists finished games and guild settings. Implementations must be safe
+// for concurrent use.
+type Store interface {
+	SaveGame(rec *GameRecord) error
+	GetGame(guildID, gameID string) (*GameRecord, error)
+
+	// ListGames returns a guild's games newest first along with the total count.
+	// A limit of 0 or less returns every game after offset.
+	ListGames(guildID string, offset, limit int) ([]*GameRecord, int, error)
+
+	// GetGuildSettings returns ErrNotFound for guilds that never changed a setting.
+	GetGuildSettings(guildID string) (*GuildSettings, error)
+	SaveGuildSettings(settings *GuildSettings) error
+}
+
+// Open creates the store for kind, where path is only used by the file backend.
+func Open(kind, path string) (Store, error) {
+	switch kind {
+	case "", KindMemory:
+		return NewMemoryStore(), nil
+	case KindFile:
+		return NewFileStore(path)
+	default:
+		return nil, fmt.Errorf("unknown store kind %q", kind)
+	}
+}
+
+type GameState string
+
+const (
+	GameFinished  GameState = "finished"
+	GameCancelled GameState = "cancelled"
+)
+
+type GameRecord struct {
+	ID           string              `json:"id"`
+	GuildID      string              `json:"guild_id"`
+	ChannelID    string              `json:"channel_id"`
+	State        GameState           `json:"state"`
+	Sponsor      string              `json:"sponsor"`
+	StartedBy    PlayerRecord        `json:"started_by"`
+	Config       GameConfigRecord    `json:"config"`
+	Entrants     []PlayerRecord      `json:"entrants"`
+	Eliminations []EliminationRecord `json:"eliminations"`
+	Victors      []PlayerRecord      `json:"victors"`
+	Awards       []AwardRecord       `json:"awards,omitempty"`
+	Rerolls      []RerollRecord      `json:"rerolls,omitempty"`
+	CreatedAt    time.Time           `json:"created_at"`
+	StartedAt    time.Time           `json:"started_at"`
+	FinishedAt   time.Time           `json:"finished_at"`
+}
+
+// Clone returns a deep copy so callers can change a record without racing the store.
+func (r *GameRecord) Clone() *GameRecord {
+	c := *r
+	c.Config.Prizes = slices.Clone(r.Config.Prizes)
+	c.Config.Weights = maps.Clone(r.Config.Weights)
+	c.Config.WeightRules = slices.Clone(r.Config.WeightRules)
+	c.Entrants = slices.Clone(r.Entrants)
+	c.Victors = slices.Clone(r.Victors)
+	c.Awards = slices.Clone(r.Awards)
+
+	c.Eliminations = slices.Clone(r.Eliminations)
+	for i, e := range c.Eliminations {
+		if e.Killer != nil {
+			killer := *e.Killer
+			c.Eliminations[i].Killer = &killer
+		}
+	}
+
+	c.Rerolls = slices.Clone(r.Rerolls)
+	for i, rr := range c.Rerolls {
+		if rr.Replacement != nil {
+			replacement := *rr.Replacement
+			c.Rerolls[i].Replacement = &replacement
+		}
+	}
+
+	return &c
+}
+
+type GameConfigRecord struct {
+	ClaimDeadline time.Duration `json:"claim_deadline,omitempty"`
+	Clone         int           `json:"clone"`
+	DayDelay      time.Duration `json:"day_delay"`
+	Delay         time.Duration `json:"delay"`
+	MinimumTier   int           `json:"minimum_tier"`
+	Pacing        string        `json:"pacing,omitempty"` // game.PacingStrategy name, empty before pacing existed
+	Prizes        []string      `json:"prizes,omitempty"`
+	ProvablyFair  bool          `json:"provably_fair"`
+	Seed          uint64        `json:"seed"`
+	Target        time.Duration `json:"target_duration,omitempty"` // 0 unless the sponsor asked for a duration
+	VictorCount   int           `json:"victor_count"`
+
+	// Weights are the survival weights by user ID that differ from 1, and
+	// WeightRules describe where they came from.
+	Weights     map[string]float64 `json:"weights,omitempty"`
+	WeightRules []string           `json:"weight_rules,omitempty"`
+}
+
+// PlayerRecord is a single tribute. Clones share the UserID of the entrant they were cloned from.
+type PlayerRecord struct {
+	UserID string `json:"user_id"`
+	Name   string `json:"name"`
+}
+
+type EliminationRecord struct {
+	Day    int           `json:"day"` // 0-based like the engine, displayed as day+1
+	Player PlayerRecord  `json:"player"`
+	Killer *PlayerRecord `json:"killer,omitempty"` // credited through the phrase's {{.Killer}} slot
+	Phrase string        `json:"phrase"`
+}
+
+// RerollRecord is a tribute disqualified with /hg-reroll and the tribute who
+// took over their prize, if anybody was left to.
+type RerollRecord struct {
+	Revoked     PlayerRecord  `json:"revoked"`
+	Replacement *PlayerRecord `json:"replacement,omitempty"`
+}
+
+// CurrentVictors returns the victors once rerolls are applied. Victors are the
+// tributes who survived the arena, while a disqualified victor no longer counts
+// and their replacement does.
+func (r *GameRecord) CurrentVictors() []PlayerRecord {
+	victors := append([]PlayerRecord(nil), r.Victors...)
+	for _, rr := range r.Rerolls {
+		was := slices.ContainsFunc(victors, func(p PlayerRecord) bool { return p.UserID == rr.Revoked.UserID })
+		if !was {
+			continue
+		}
+
+		victors = slices.DeleteFunc(victors, func(p PlayerRecord) bool { return p.UserID == rr.Revoked.UserID })
+		if rr.Replacement != nil && !slices.ContainsFunc(victors, func(p PlayerRecord) bool { return p.UserID == rr.Replacement.UserID }) {
+			victors = append(victors, *rr.Replacement)
+		}
+	}
+
+	return victors
+}
+
+// AwardRecord is one holder of a prize slot. Rerolled slots have several, the
+// latest being the current holder.
+type AwardRecord struct {
+	Slot   int          `json:"slot"`
+	Prize  string       `json:"prize"`
+	Place  int          `json:"place"`
+	Player PlayerRecord `json:"player"`
+	Status string       `json:"status,omitempty"` // claim status when claims were enabled
+
+	Deadline time.Time `json:"deadline,omitzero"` // when a pending claim expires
+}
@@ -0,0 +1,241 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func testStores(t *testing.T) map[string]Store {
+	t.Helper()
+
+	fs, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return map[string]Store{
+		KindMemory: NewMemoryStore(),
+		KindFile:   fs,
+	}
+}
+
+func testGameRecord(guildID string, i int) *GameRecord {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(i) * time.Hour)
+	return &GameRecord{
+		ID:         fmt.Sprintf("game-%v", i),
+		GuildID:    guildID,
+		State:      GameFinished,
+		Entrants:   []PlayerRecord{{UserID: "1", Name: "one"}, {UserID: "2", Name: "two"}},
+		Victors:    []PlayerRecord{{UserID: "1", Name: "one"}},
+		CreatedAt:  created,
+		FinishedAt: created.Add(time.Minute),
+	}
+}
+
+func TestStore_SaveListGet(t *testing.T) {
+	for name, store := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i < 5; i++ {
+				if err := store.SaveGame(testGameRecord("guild", i)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			store.SaveGame(testGameRecord("other-guild", 9))
+
+			page, total, err := store.ListGames("guild", 1, 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if total != 5 {
+				t.Fatalf("expected 5 games but got %v", total)
+			}
+
+			if len(page) != 2 || page[0].ID != "game-3" || page[1].ID != "game-2" {
+				t.Fatalf("expected games 3 and 2 newest first, got %v", page)
+			}
+
+			rec, err := store.GetGame("guild", "game-4")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if rec.Victors[0].UserID != "1" {
+				t.Errorf("expected victor 1 but got %v", rec.Victors[0].UserID)
+			}
+
+			if _, err := store.GetGame("other-guild", "game-4"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("expected ErrNotFound across guilds but got %v", err)
+			}
+		})
+	}
+}
+
+func TestStore_SaveGameReplacesExisting(t *testing.T) {
+	for name, store := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			rec := testGameRecord("guild", 1)
+			store.SaveGame(rec)
+
+			updated := testGameRecord("guild", 1)
+			updated.State = GameCancelled
+			store.SaveGame(updated)
+
+			games, total, _ := store.ListGames("guild", 0, 0)
+			if total != 1 || games[0].State != GameCancelled {
+				t.Fatalf("expected a single cancelled game but got %v games", total)
+			}
+		})
+	}
+}
+
+func TestStore_GamesAreCopies(t *testing.T) {
+	for name, store := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			rec := testGameRecord("guild", 1)
+			store.SaveGame(rec)
+			rec.Victors[0].UserID = "changed after saving"
+
+			got, _ := store.GetGame("guild", "game-1")
+			got.Victors[0].UserID = "changed after getting"
+
+			games, _, _ := store.ListGames("guild", 0, 0)
+			games[0].Entrants = nil
+
+			got, _ = store.GetGame("guild", "game-1")
+			if got.Victors[0].UserID != "1" || len(got.Entrants) != 2 {
+				t.Errorf("expected the stored record to be unchanged but got %+v", got)
+			}
+		})
+	}
+}
+
+func TestFileStore_ConcurrentSaves(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rec := testGameRecord("guild", 1)
+			rec.Sponsor = strings.Repeat("x", i*100)
+			if err := store.SaveGame(rec); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	reopened, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := reopened.GetGame("guild", "game-1"); err != nil {
+		t.Errorf("expected the record to survive concurrent saves but got %v", err)
+	}
+}
+
+func TestFileStore_Reopen(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		store.SaveGame(testGameRecord("guild", i))
+	}
+
+	reopened, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	games, total, err := reopened.ListGames("guild", 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if total != 3 || games[0].ID != "game-2" {
+		t.Fatalf("expected 3 games with game-2 first after reopening, got %v", total)
+	}
+}
+
+func TestStore_GuildSettings(t *testing.T) {
+	for name, store := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			if _, err := store.GetGuildSettings("guild"); !errors.Is(err, ErrNotFound) {
+				t.Fatalf("expected ErrNotFound before saving but got %v", err)
+			}
+
+			gs := &GuildSettings{
+				GuildID:     "guild",
+				Emojis:      map[string]EmojiRecord{"Participant": {Name: "hg", ID: "1"}},
+				Permissions: map[string]PermissionRule{"cancel": {Roles: []string{"mods"}}},
+				Winners:     &WinnerPolicy{CooldownGames: 2},
+			}
+			if err := store.SaveGuildSettings(gs); err != nil {
+				t.Fatal(err)
+			}
+
+			// Changing the saved value must not change the stored settings.
+			gs.Permissions["cancel"] = PermissionRule{}
+			gs.Emojis["Participant"] = EmojiRecord{}
+			gs.Winners.CooldownGames = 0
+
+			got, err := store.GetGuildSettings("guild")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if rule := got.Permissions["cancel"]; len(rule.Roles) != 1 || rule.Roles[0] != "mods" {
+				t.Errorf("expected the mods role to be allowed to cancel but got %+v", rule)
+			}
+
+			if emoji := got.Emojis["Participant"]; emoji.Name != "hg" {
+				t.Errorf("expected the hg participant emoji but got %+v", emoji)
+			}
+
+			if got.Winners == nil || got.Winners.CooldownGames != 2 {
+				t.Errorf("expected a cooldown of 2 games but got %+v", got.Winners)
+			}
+		})
+	}
+}
+
+func TestFileStore_ReopenGuildSettings(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store.SaveGuildSettings(&GuildSettings{
+		GuildID:     "guild",
+		Permissions: map[string]PermissionRule{"start": {Users: []string{"1"}}},
+	})
+
+	reopened, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := reopened.GetGuildSettings("guild")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rule := got.Permissions["start"]; len(rule.Users) != 1 || rule.Users[0] != "1" {
+		t.Errorf("expected user 1 to be allowed to start after reopening but got %+v", rule)
+	}
+}